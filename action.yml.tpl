@@ -3,20 +3,40 @@ description: >
   Gets the next version for your repository according to
   semantic versioning based on conventional commits.
 inputs:
+  config:
+    description: 'Sets the path to the configuration file (defaults to .get-next-version.{yaml,yml,toml,json} at the repository root)'
+    required: false
+    default: ''
   prefix:
     description: 'Sets the version prefix'
     required: false
     default: ''
+  commit_convention:
+    description: 'Sets the commit message convention (conventional, gitmoji or regex, defaults to conventional)'
+    required: false
+    default: ''
+  commit_pattern:
+    description: 'Sets the regex with named groups type, scope, breaking and description for the regex commit convention'
+    required: false
+    default: ''
   feature_prefixes:
-    description: 'Sets custom feature prefixes (comma-separated)'
+    description: 'Sets custom feature prefixes (comma-separated, cannot be combined with bump-rules in the configuration file)'
     required: false
     default: ''
   fix_prefixes:
-    description: 'Sets custom fix prefixes (comma-separated)'
+    description: 'Sets custom fix prefixes (comma-separated, cannot be combined with bump-rules in the configuration file)'
     required: false
     default: ''
   chore_prefixes:
-    description: 'Sets custom chore prefixes (comma-separated)'
+    description: 'Sets custom chore prefixes (comma-separated, cannot be combined with bump-rules in the configuration file)'
+    required: false
+    default: ''
+  strict:
+    description: 'Fails if a commit since the latest release is not a conventional commit (true or false)'
+    required: false
+    default: ''
+  non_conventional_as:
+    description: 'Sets the version bump for commits that are not conventional commits (none, patch or minor)'
     required: false
     default: ''
   tags_filter_regex:
@@ -27,15 +47,121 @@ inputs:
     description: 'Sets a regex to filter commits by path'
     required: false
     default: ''
+  include_scopes:
+    description: 'Only counts commits with a scope matching one of the globs or /regexes/ (one per line)'
+    required: false
+    default: ''
+  exclude_scopes:
+    description: 'Ignores commits whose scopes all match one of the globs or /regexes/ (one per line)'
+    required: false
+    default: ''
+  unscoped_commits:
+    description: 'Sets whether commits without scope are counted when filtering by scope (include or exclude)'
+    required: false
+    default: ''
+  skip_authors:
+    description: 'Skips commits whose author (name <email>) matches the regex'
+    required: false
+    default: ''
+  skip_committers:
+    description: 'Skips commits whose committer (name <email>) matches the regex'
+    required: false
+    default: ''
+  skip_trailers:
+    description: 'Skips commits with one of the trailers, as token or token: value (comma-separated)'
+    required: false
+    default: ''
+  skip_markers:
+    description: 'Skips commits whose subject contains one of the markers, e.g. [skip release] (comma-separated)'
+    required: false
+    default: ''
   version_regex:
     description: 'Sets a regex to extract the version from tags'
     required: false
     default: ''
+  baseline_strategy:
+    description: 'Sets how the latest release is chosen among the tags reachable from HEAD (nearest or highest-reachable)'
+    required: false
+    default: ''
+  on_version_collision:
+    description: 'Sets what happens when the next version is already tagged (error or skip)'
+    required: false
+    default: ''
+  version_scheme:
+    description: 'Sets the versioning scheme (semver or calver, defaults to semver)'
+    required: false
+    default: ''
+  calver_format:
+    description: 'Sets the format of calendar versions, e.g. YY.0M.MICRO (defaults to YYYY.MM.MICRO)'
+    required: false
+    default: ''
+  initial_development:
+    description: 'Applies initial development (0.x) rules while the major version is 0 (true or false)'
+    required: false
+    default: ''
+  initial_development_feature_bump:
+    description: 'Sets the version bump for features during initial development (patch or minor)'
+    required: false
+    default: ''
+  graduate:
+    description: 'Releases 1.0.0 if the major version is 0 (true or false)'
+    required: false
+    default: ''
+  snapshot:
+    description: 'Computes a unique development version even without releasable changes, e.g. 1.4.0-dev.7+g3f2a9c1 (true or false)'
+    required: false
+    default: ''
+  snapshot_identifier:
+    description: 'Sets the pre-release identifier of snapshot versions (defaults to dev)'
+    required: false
+    default: ''
+  snapshot_metadata:
+    description: 'Sets the build metadata of snapshot versions (hash, date and/or dirty, comma-separated)'
+    required: false
+    default: ''
+  prerelease:
+    description: 'Sets the pre-release channel (e.g. rc produces 2.0.0-rc.1, 2.0.0-rc.2, ...)'
+    required: false
+    default: ''
+  branch:
+    description: 'Sets the branch used to select branch rules from the configuration file'
+    required: false
+    default: ''
+  from:
+    description: 'Sets the revision to count commits from instead of the latest release'
+    required: false
+    default: ''
+  to:
+    description: 'Sets the revision to compute the next version for (defaults to HEAD)'
+    required: false
+    default: ''
+  deepen_remote:
+    description: 'Fetches more history and tags from the given remote (e.g. origin) when a shallow clone ends before the latest release, authenticating with push_token'
+    required: false
+    default: ''
+  create_tag:
+    description: 'Creates the tag for the next version on HEAD'
+    required: false
+    default: ''
+  tag_message:
+    description: 'Sets the tag message template (creates an annotated tag)'
+    required: false
+    default: ''
+  tag_remote:
+    description: 'Pushes the created tag to the given remote'
+    required: false
+    default: ''
+  push_token:
+    description: 'Sets the token used to push the tag'
+    required: false
+    default: ''
 outputs:
   version:
     description: 'Next version'
   hasNextVersion:
     description: 'Whether there is a next version'
+  components:
+    description: 'Next version of every component as JSON, when components are configured'
 runs:
   using: 'docker'
   image: '<docker-image>'
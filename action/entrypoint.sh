@@ -2,13 +2,48 @@
 
 set -e
 
-/action/get-next-version \
-  --repository /github/workspace \
-  --target github-action \
-  --prefix "$INPUT_PREFIX" \
-  --feature-prefixes "$INPUT_FEATURE_PREFIXES" \
-  --fix-prefixes "$INPUT_FIX_PREFIXES" \
-  --chore-prefixes "$INPUT_CHORE_PREFIXES" \
-  --tags-filter-regex "$INPUT_TAGS_FILTER_REGEX" \
-  --commits-filter-path-regex "$INPUT_COMMITS_FILTER_PATH_REGEX" \
-  --version-regex "$INPUT_VERSION_REGEX"
+# Only forward inputs that were actually set, so that values from the
+# repository configuration file are not overridden by empty flags.
+set -- --repository /github/workspace --target github-action
+
+[ -n "$INPUT_CONFIG" ] && set -- "$@" --config "$INPUT_CONFIG"
+[ -n "$INPUT_PREFIX" ] && set -- "$@" --prefix "$INPUT_PREFIX"
+[ -n "$INPUT_COMMIT_CONVENTION" ] && set -- "$@" --commit-convention "$INPUT_COMMIT_CONVENTION"
+[ -n "$INPUT_COMMIT_PATTERN" ] && set -- "$@" --commit-pattern "$INPUT_COMMIT_PATTERN"
+[ -n "$INPUT_FEATURE_PREFIXES" ] && set -- "$@" --feature-prefixes "$INPUT_FEATURE_PREFIXES"
+[ -n "$INPUT_FIX_PREFIXES" ] && set -- "$@" --fix-prefixes "$INPUT_FIX_PREFIXES"
+[ -n "$INPUT_CHORE_PREFIXES" ] && set -- "$@" --chore-prefixes "$INPUT_CHORE_PREFIXES"
+[ "$INPUT_STRICT" = "true" ] && set -- "$@" --strict
+[ -n "$INPUT_NON_CONVENTIONAL_AS" ] && set -- "$@" --non-conventional-as "$INPUT_NON_CONVENTIONAL_AS"
+[ -n "$INPUT_TAGS_FILTER_REGEX" ] && set -- "$@" --tags-filter-regex "$INPUT_TAGS_FILTER_REGEX"
+[ -n "$INPUT_COMMITS_FILTER_PATH_REGEX" ] && set -- "$@" --commits-filter-path-regex "$INPUT_COMMITS_FILTER_PATH_REGEX"
+# Scope lists are newline-separated, which only the environment variables split.
+[ -n "$INPUT_INCLUDE_SCOPES" ] && export GNV_INCLUDE_SCOPES="$INPUT_INCLUDE_SCOPES"
+[ -n "$INPUT_EXCLUDE_SCOPES" ] && export GNV_EXCLUDE_SCOPES="$INPUT_EXCLUDE_SCOPES"
+[ -n "$INPUT_UNSCOPED_COMMITS" ] && set -- "$@" --unscoped-commits "$INPUT_UNSCOPED_COMMITS"
+[ -n "$INPUT_SKIP_AUTHORS" ] && set -- "$@" --skip-authors "$INPUT_SKIP_AUTHORS"
+[ -n "$INPUT_SKIP_COMMITTERS" ] && set -- "$@" --skip-committers "$INPUT_SKIP_COMMITTERS"
+[ -n "$INPUT_SKIP_TRAILERS" ] && set -- "$@" --skip-trailers "$INPUT_SKIP_TRAILERS"
+[ -n "$INPUT_SKIP_MARKERS" ] && set -- "$@" --skip-markers "$INPUT_SKIP_MARKERS"
+[ -n "$INPUT_VERSION_REGEX" ] && set -- "$@" --version-regex "$INPUT_VERSION_REGEX"
+[ -n "$INPUT_BASELINE_STRATEGY" ] && set -- "$@" --baseline-strategy "$INPUT_BASELINE_STRATEGY"
+[ -n "$INPUT_ON_VERSION_COLLISION" ] && set -- "$@" --on-version-collision "$INPUT_ON_VERSION_COLLISION"
+[ -n "$INPUT_VERSION_SCHEME" ] && set -- "$@" --version-scheme "$INPUT_VERSION_SCHEME"
+[ -n "$INPUT_CALVER_FORMAT" ] && set -- "$@" --calver-format "$INPUT_CALVER_FORMAT"
+[ "$INPUT_INITIAL_DEVELOPMENT" = "true" ] && set -- "$@" --initial-development
+[ -n "$INPUT_INITIAL_DEVELOPMENT_FEATURE_BUMP" ] && set -- "$@" --initial-development-feature-bump "$INPUT_INITIAL_DEVELOPMENT_FEATURE_BUMP"
+[ "$INPUT_GRADUATE" = "true" ] && set -- "$@" --graduate
+[ "$INPUT_SNAPSHOT" = "true" ] && set -- "$@" --snapshot
+[ -n "$INPUT_SNAPSHOT_IDENTIFIER" ] && set -- "$@" --snapshot-identifier "$INPUT_SNAPSHOT_IDENTIFIER"
+[ -n "$INPUT_SNAPSHOT_METADATA" ] && set -- "$@" --snapshot-metadata "$INPUT_SNAPSHOT_METADATA"
+[ -n "$INPUT_PRERELEASE" ] && set -- "$@" --prerelease "$INPUT_PRERELEASE"
+[ -n "$INPUT_BRANCH" ] && set -- "$@" --branch "$INPUT_BRANCH"
+[ -n "$INPUT_FROM" ] && set -- "$@" --from "$INPUT_FROM"
+[ -n "$INPUT_TO" ] && set -- "$@" --to "$INPUT_TO"
+[ -n "$INPUT_DEEPEN_REMOTE" ] && set -- "$@" --deepen-remote "$INPUT_DEEPEN_REMOTE"
+[ "$INPUT_CREATE_TAG" = "true" ] && set -- "$@" --create-tag
+[ -n "$INPUT_TAG_MESSAGE" ] && set -- "$@" --tag-message "$INPUT_TAG_MESSAGE"
+[ -n "$INPUT_TAG_REMOTE" ] && set -- "$@" --tag-remote "$INPUT_TAG_REMOTE"
+[ -n "$INPUT_PUSH_TOKEN" ] && export GNV_PUSH_TOKEN="$INPUT_PUSH_TOKEN"
+
+/action/get-next-version "$@"
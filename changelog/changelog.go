@@ -0,0 +1,107 @@
+package changelog
+
+import (
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+type Section struct {
+	Title    string
+	Types    []string
+	Breaking bool
+}
+
+var DefaultSections = []Section{
+	{Title: "Breaking Changes", Breaking: true},
+	{Title: "Features", Types: []string{"feat"}},
+	{Title: "Bug Fixes", Types: []string{"fix"}},
+	{Title: "Performance Improvements", Types: []string{"perf"}},
+}
+
+type Entry struct {
+	Hash        string `json:"hash"`
+	Type        string `json:"type"`
+	Scope       string `json:"scope,omitempty"`
+	Description string `json:"description"`
+	Breaking    bool   `json:"breaking"`
+}
+
+type ReleaseSection struct {
+	Title   string  `json:"title"`
+	Entries []Entry `json:"entries"`
+}
+
+type Release struct {
+	Version  string           `json:"version"`
+	Date     *time.Time       `json:"date,omitempty"`
+	Sections []ReleaseSection `json:"sections"`
+}
+
+func (s Section) matches(entry Entry, hasBreakingSection bool) bool {
+	if s.Breaking {
+		return entry.Breaking
+	}
+	if entry.Breaking && hasBreakingSection {
+		return false
+	}
+	return slices.Contains(s.Types, entry.Type)
+}
+
+/*
+Build groups the analyzed commits into the given sections.
+Commits that were filtered out or are not conventional commits are left out.
+Breaking changes are listed in the breaking section only, if there is one,
+otherwise in the section of their type.
+Every commit is placed in the first matching section and entries are sorted
+by scope, with unscoped entries first.
+*/
+func Build(version string, date *time.Time, commits []git.AnalyzedCommit, sections []Section) Release {
+	if len(sections) == 0 {
+		sections = DefaultSections
+	}
+
+	hasBreakingSection := slices.ContainsFunc(sections, func(section Section) bool { return section.Breaking })
+
+	releaseSections := make([]ReleaseSection, len(sections))
+	for i, section := range sections {
+		releaseSections[i] = ReleaseSection{Title: section.Title, Entries: []Entry{}}
+	}
+
+	for _, commit := range commits {
+		if !commit.Included || commit.Classification.CommitType == "" {
+			continue
+		}
+
+		entry := Entry{
+			Hash:        commit.Hash.String(),
+			Type:        strings.ToLower(commit.Classification.CommitType),
+			Scope:       commit.Classification.Scope,
+			Description: commit.Classification.Description,
+			Breaking:    commit.Classification.Breaking,
+		}
+
+		for i, section := range sections {
+			if section.matches(entry, hasBreakingSection) {
+				releaseSections[i].Entries = append(releaseSections[i].Entries, entry)
+				break
+			}
+		}
+	}
+
+	release := Release{Version: version, Date: date, Sections: []ReleaseSection{}}
+	for _, releaseSection := range releaseSections {
+		if len(releaseSection.Entries) == 0 {
+			continue
+		}
+		sort.SliceStable(releaseSection.Entries, func(i, j int) bool {
+			return releaseSection.Entries[i].Scope < releaseSection.Entries[j].Scope
+		})
+		release.Sections = append(release.Sections, releaseSection)
+	}
+
+	return release
+}
@@ -0,0 +1,81 @@
+package changelog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/tvcsantos/get-next-version/changelog"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+func createCommit(hash string, message string, included bool) git.AnalyzedCommit {
+	classification, _ := conventionalcommits.ClassifyCommitMessage(message, conventionalcommits.NewTypeClassifier())
+	return git.AnalyzedCommit{
+		Hash:           plumbing.NewHash(hash),
+		Subject:        message,
+		Message:        message,
+		Classification: classification,
+		Included:       included,
+	}
+}
+
+var commits = []git.AnalyzedCommit{
+	createCommit("1111111111111111111111111111111111111111", "feat(web): add dark mode", true),
+	createCommit("2222222222222222222222222222222222222222", "fix: correct typo", true),
+	createCommit("3333333333333333333333333333333333333333", "feat(api)!: remove v1 endpoints", true),
+	createCommit("4444444444444444444444444444444444444444", "feat: add search", true),
+	createCommit("5555555555555555555555555555555555555555", "chore: tidy up", true),
+	createCommit("6666666666666666666666666666666666666666", "Merge branch 'main'", true),
+	createCommit("7777777777777777777777777777777777777777", "fix(docs): filtered out", false),
+}
+
+func TestBuild(t *testing.T) {
+	date := time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+
+	t.Run("uses the default sections", func(t *testing.T) {
+		release := changelog.Build("2.0.0", &date, commits, nil)
+
+		assert.Equal(t, "2.0.0", release.Version)
+		assert.Equal(t, &date, release.Date)
+		assert.Equal(t, []changelog.ReleaseSection{
+			{Title: "Breaking Changes", Entries: []changelog.Entry{
+				{Hash: "3333333333333333333333333333333333333333", Type: "feat", Scope: "api", Description: "remove v1 endpoints", Breaking: true},
+			}},
+			{Title: "Features", Entries: []changelog.Entry{
+				{Hash: "4444444444444444444444444444444444444444", Type: "feat", Description: "add search"},
+				{Hash: "1111111111111111111111111111111111111111", Type: "feat", Scope: "web", Description: "add dark mode"},
+			}},
+			{Title: "Bug Fixes", Entries: []changelog.Entry{
+				{Hash: "2222222222222222222222222222222222222222", Type: "fix", Description: "correct typo"},
+			}},
+		}, release.Sections)
+	})
+
+	t.Run("uses custom sections", func(t *testing.T) {
+		release := changelog.Build("2.0.0", nil, commits, []changelog.Section{
+			{Title: "Changes", Types: []string{"feat", "fix"}},
+			{Title: "Maintenance", Types: []string{"chore"}},
+		})
+
+		assert.Equal(t, []changelog.ReleaseSection{
+			{Title: "Changes", Entries: []changelog.Entry{
+				{Hash: "2222222222222222222222222222222222222222", Type: "fix", Description: "correct typo"},
+				{Hash: "4444444444444444444444444444444444444444", Type: "feat", Description: "add search"},
+				{Hash: "3333333333333333333333333333333333333333", Type: "feat", Scope: "api", Description: "remove v1 endpoints", Breaking: true},
+				{Hash: "1111111111111111111111111111111111111111", Type: "feat", Scope: "web", Description: "add dark mode"},
+			}},
+			{Title: "Maintenance", Entries: []changelog.Entry{
+				{Hash: "5555555555555555555555555555555555555555", Type: "chore", Description: "tidy up"},
+			}},
+		}, release.Sections)
+	})
+
+	t.Run("omits empty sections", func(t *testing.T) {
+		release := changelog.Build("1.0.1", nil, commits[4:], nil)
+
+		assert.Equal(t, []changelog.ReleaseSection{}, release.Sections)
+	})
+}
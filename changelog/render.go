@@ -0,0 +1,74 @@
+package changelog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const header = "# Changelog\n\nAll notable changes to this project will be documented in this file.\n"
+
+func RenderMarkdown(release Release) string {
+	var builder strings.Builder
+
+	if release.Date != nil {
+		fmt.Fprintf(&builder, "## [%s] - %s\n", release.Version, release.Date.Format("2006-01-02"))
+	} else {
+		fmt.Fprintf(&builder, "## [%s]\n", release.Version)
+	}
+
+	for _, section := range release.Sections {
+		fmt.Fprintf(&builder, "\n### %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			builder.WriteString("- ")
+			if entry.Scope != "" {
+				fmt.Fprintf(&builder, "**%s:** ", entry.Scope)
+			}
+			fmt.Fprintf(&builder, "%s (%s)\n", entry.Description, shortHash(entry.Hash))
+		}
+	}
+
+	return builder.String()
+}
+
+func RenderJSON(release Release) ([]byte, error) {
+	type jsonRelease struct {
+		Version  string           `json:"version"`
+		Date     string           `json:"date,omitempty"`
+		Sections []ReleaseSection `json:"sections"`
+	}
+
+	output := jsonRelease{Version: release.Version, Sections: release.Sections}
+	if release.Date != nil {
+		output.Date = release.Date.Format("2006-01-02")
+	}
+
+	return json.MarshalIndent(output, "", "  ")
+}
+
+// Prepend inserts the rendered release in front of the first release of an
+// existing changelog, keeping any introduction above it untouched.
+func Prepend(existing string, renderedRelease string) string {
+	if strings.TrimSpace(existing) == "" {
+		return header + "\n" + renderedRelease
+	}
+
+	lines := strings.SplitAfter(existing, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "## ") {
+			return strings.Join(lines[:i], "") + renderedRelease + "\n" + strings.Join(lines[i:], "")
+		}
+	}
+
+	if !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+	return existing + "\n" + renderedRelease
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
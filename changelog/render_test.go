@@ -0,0 +1,80 @@
+package changelog_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/changelog"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	date := time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, `## [2.0.0] - 2024-05-17
+
+### Breaking Changes
+
+- **api:** remove v1 endpoints (3333333)
+
+### Features
+
+- add search (4444444)
+- **web:** add dark mode (1111111)
+
+### Bug Fixes
+
+- correct typo (2222222)
+`, changelog.RenderMarkdown(changelog.Build("2.0.0", &date, commits, nil)))
+
+	assert.Equal(t, "## [Unreleased]\n", changelog.RenderMarkdown(changelog.Build("Unreleased", nil, nil, nil)))
+}
+
+func TestRenderJSON(t *testing.T) {
+	date := time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC)
+
+	output, err := changelog.RenderJSON(changelog.Build("1.0.1", &date, commits[1:2], nil))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"version": "1.0.1",
+		"date": "2024-05-17",
+		"sections": [
+			{"title": "Bug Fixes", "entries": [
+				{"hash": "2222222222222222222222222222222222222222", "type": "fix", "description": "correct typo", "breaking": false}
+			]}
+		]
+	}`, string(output))
+}
+
+func TestPrepend(t *testing.T) {
+	release := "## [1.1.0]\n\n### Features\n\n- something (1234567)\n"
+
+	tests := []struct {
+		name     string
+		existing string
+		expected string
+	}{
+		{
+			name:     "creates a new changelog",
+			existing: "",
+			expected: "# Changelog\n\nAll notable changes to this project will be documented in this file.\n\n" + release,
+		},
+		{
+			name:     "inserts before the latest release",
+			existing: "# Changelog\n\nIntro.\n\n## [1.0.0]\n\n- first\n",
+			expected: "# Changelog\n\nIntro.\n\n" + release + "\n## [1.0.0]\n\n- first\n",
+		},
+		{
+			name:     "appends to a changelog without releases",
+			existing: "# Changelog\n\nIntro.",
+			expected: "# Changelog\n\nIntro.\n\n" + release,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expected, changelog.Prepend(test.existing, release))
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tvcsantos/get-next-version/changelog"
+	"github.com/tvcsantos/get-next-version/config"
+	"github.com/tvcsantos/get-next-version/nextversion"
+)
+
+var (
+	changelogOutputFlag  string
+	changelogPrependFlag string
+)
+
+func init() {
+	ChangelogCommand.Flags().StringVarP(&changelogOutputFlag, "output", "o", "markdown", "sets the output format (markdown or json)")
+	ChangelogCommand.Flags().StringVar(&changelogPrependFlag, "prepend", "", "prepends the release notes to the given changelog file in place instead of printing them")
+
+	RootCommand.AddCommand(ChangelogCommand)
+}
+
+var ChangelogCommand = &cobra.Command{
+	Use:   "changelog",
+	Short: "Generates release notes for the next version",
+	Long:  "Generates release notes for the next version from the commits since the latest release.",
+	RunE: func(command *cobra.Command, _ []string) error {
+		repository, resolved, err := openRepositoryWithConfig(command)
+		if err != nil {
+			return err
+		}
+
+		result, err := nextversion.Compute(command.Context(), repository, createOptions(resolved.Config))
+		if err != nil {
+			return err
+		}
+
+		release := buildChangelogRelease(result, resolved.Config)
+
+		switch changelogOutputFlag {
+		case "markdown":
+			renderedRelease := changelog.RenderMarkdown(release)
+			if changelogPrependFlag == "" {
+				fmt.Print(renderedRelease)
+				return nil
+			}
+			return prependToChangelogFile(changelogPrependFlag, renderedRelease)
+		case "json":
+			if changelogPrependFlag != "" {
+				return fmt.Errorf("--prepend is only supported for markdown output")
+			}
+			output, err := changelog.RenderJSON(release)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		default:
+			return fmt.Errorf("invalid output format %+q", changelogOutputFlag)
+		}
+	},
+}
+
+func buildChangelogRelease(result nextversion.Result, cfg config.Config) changelog.Release {
+	sections := make([]changelog.Section, len(cfg.ChangelogSections))
+	for i, section := range cfg.ChangelogSections {
+		sections[i] = changelog.Section{
+			Title:    section.Title,
+			Types:    section.Types,
+			Breaking: section.Breaking,
+		}
+	}
+
+	if !result.HasNextVersion {
+		return changelog.Build("Unreleased", nil, result.Commits, sections)
+	}
+
+	date := time.Now().UTC()
+	return changelog.Build(result.FormatVersion(result.Version), &date, result.Commits, sections)
+}
+
+func prependToChangelogFile(path string, renderedRelease string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("could not read changelog: %w", err)
+	}
+
+	err = os.WriteFile(path, []byte(changelog.Prepend(string(existing), renderedRelease)), 0644)
+	if err != nil {
+		return fmt.Errorf("could not write changelog: %w", err)
+	}
+
+	return nil
+}
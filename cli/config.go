@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+var configShowOutputFlag string
+
+func init() {
+	ConfigShowCommand.Flags().StringVarP(&configShowOutputFlag, "output", "o", "text", "sets the output format (text or json)")
+
+	ConfigCommand.AddCommand(ConfigShowCommand)
+	RootCommand.AddCommand(ConfigCommand)
+}
+
+var ConfigCommand = &cobra.Command{
+	Use:   "config",
+	Short: "Inspects the get-next-version configuration",
+	Long:  "Inspects the get-next-version configuration.",
+}
+
+var ConfigShowCommand = &cobra.Command{
+	Use:   "show",
+	Short: "Prints the effective configuration and where each value came from",
+	Long:  "Prints the effective configuration and where each value came from.",
+	RunE: func(command *cobra.Command, _ []string) error {
+		_, resolved, err := openRepositoryWithConfig(command)
+		if err != nil {
+			return err
+		}
+
+		switch configShowOutputFlag {
+		case "json":
+			type jsonSetting struct {
+				Value  any    `json:"value"`
+				Source string `json:"source"`
+			}
+			settings := map[string]jsonSetting{}
+			for _, setting := range resolved.Settings() {
+				settings[setting.Key] = jsonSetting{Value: setting.Value, Source: string(setting.Source)}
+			}
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			return encoder.Encode(struct {
+				File     string                 `json:"file"`
+				Settings map[string]jsonSetting `json:"settings"`
+			}{File: resolved.File, Settings: settings})
+		case "text":
+			if resolved.File != "" {
+				fmt.Printf("config file: %s\n\n", resolved.File)
+			} else {
+				fmt.Printf("config file: (none)\n\n")
+			}
+			writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+			fmt.Fprintln(writer, "KEY\tVALUE\tSOURCE")
+			for _, setting := range resolved.Settings() {
+				fmt.Fprintf(writer, "%s\t%s\t%s\n", setting.Key, formatSettingValue(setting.Value), setting.Source)
+			}
+			return writer.Flush()
+		default:
+			return fmt.Errorf("invalid output format %+q", configShowOutputFlag)
+		}
+	},
+}
+
+func formatSettingValue(value any) string {
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue
+	case []string:
+		return strings.Join(typedValue, ",")
+	default:
+		encoded, err := json.Marshal(typedValue)
+		if err != nil {
+			return fmt.Sprintf("%v", typedValue)
+		}
+		return string(encoded)
+	}
+}
@@ -0,0 +1,31 @@
+package cli
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	ExitCodeError        = 1
+	ExitCodeLintProblems = 1
+	ExitCodeLintUsage    = 2
+)
+
+// ExitCode returns the exit status for the error returned by the given
+// command. The lint command tells problems in commit messages apart from
+// errors that prevent it from running, such as invalid flags or a missing
+// repository.
+func ExitCode(command *cobra.Command, err error) int {
+	var problemsErr *LintProblemsError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &problemsErr):
+		return ExitCodeLintProblems
+	case command == LintCommand:
+		return ExitCodeLintUsage
+	default:
+		return ExitCodeError
+	}
+}
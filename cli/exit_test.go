@@ -0,0 +1,63 @@
+package cli_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/tvcsantos/get-next-version/cli"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name             string
+		command          *cobra.Command
+		err              error
+		expectedExitCode int
+	}{
+		{
+			name:             "succeeds without error",
+			command:          cli.LintCommand,
+			err:              nil,
+			expectedExitCode: 0,
+		},
+		{
+			name:             "reports lint problems",
+			command:          cli.LintCommand,
+			err:              &cli.LintProblemsError{Problems: 2},
+			expectedExitCode: cli.ExitCodeLintProblems,
+		},
+		{
+			name:             "reports wrapped lint problems",
+			command:          cli.LintCommand,
+			err:              fmt.Errorf("lint: %w", &cli.LintProblemsError{Problems: 1}),
+			expectedExitCode: cli.ExitCodeLintProblems,
+		},
+		{
+			name:             "reports lint usage errors",
+			command:          cli.LintCommand,
+			err:              errors.New("exactly one of --file and --range is required"),
+			expectedExitCode: cli.ExitCodeLintUsage,
+		},
+		{
+			name:             "reports errors of other commands",
+			command:          cli.RootCommand,
+			err:              errors.New("repository does not exist"),
+			expectedExitCode: cli.ExitCodeError,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.expectedExitCode, cli.ExitCode(test.command, test.err))
+		})
+	}
+}
+
+func TestLintProblemsError(t *testing.T) {
+	err := &cli.LintProblemsError{Problems: 3}
+
+	assert.EqualError(t, err, "found 3 problems in commit messages")
+}
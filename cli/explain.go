@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/nextversion"
+)
+
+var explainOutputFlag string
+
+func init() {
+	ExplainCommand.Flags().StringVarP(&explainOutputFlag, "output", "o", "text", "sets the output format (text or json)")
+
+	RootCommand.AddCommand(ExplainCommand)
+}
+
+var ExplainCommand = &cobra.Command{
+	Use:   "explain",
+	Short: "Explains how the next version was determined",
+	Long:  "Explains how the next version was determined, listing the baseline release and every analyzed commit.",
+	RunE: func(command *cobra.Command, _ []string) error {
+		repository, resolved, err := openRepositoryWithConfig(command)
+		if err != nil {
+			return err
+		}
+
+		result, err := nextversion.Compute(command.Context(), repository, createOptions(resolved.Config))
+		if err != nil {
+			return err
+		}
+
+		switch explainOutputFlag {
+		case "json":
+			return writeExplanationJSON(os.Stdout, result)
+		case "text":
+			return writeExplanationText(os.Stdout, result)
+		default:
+			return fmt.Errorf("invalid output format %+q", explainOutputFlag)
+		}
+	},
+}
+
+type explainedCommit struct {
+	Hash      string `json:"hash"`
+	Subject   string `json:"subject"`
+	Type      string `json:"type"`
+	Scope     string `json:"scope"`
+	Breaking  bool   `json:"breaking"`
+	ReleaseAs string `json:"releaseAs,omitempty"`
+	Included  bool   `json:"included"`
+	Reason    string `json:"reason"`
+}
+
+type explainedRevert struct {
+	Revert   string `json:"revert"`
+	Reverted string `json:"reverted"`
+}
+
+type explanation struct {
+	Version           string            `json:"version"`
+	HasNextVersion    bool              `json:"hasNextVersion"`
+	PreviousVersion   string            `json:"previousVersion"`
+	PreReleaseChannel string            `json:"preReleaseChannel"`
+	Snapshot          bool              `json:"snapshot"`
+	BaselineTag       string            `json:"baselineTag"`
+	BaselineCommit    string            `json:"baselineCommit"`
+	HeadCommit        string            `json:"headCommit"`
+	Commits           []explainedCommit `json:"commits"`
+	DeterminingCommit *explainedCommit  `json:"determiningCommit"`
+	Reverts           []explainedRevert `json:"reverts"`
+}
+
+func toExplainedCommit(analyzedCommit git.AnalyzedCommit) explainedCommit {
+	commit := explainedCommit{
+		Hash:     analyzedCommit.Hash.String(),
+		Subject:  analyzedCommit.Subject,
+		Type:     analyzedCommit.Classification.Type.String(),
+		Scope:    analyzedCommit.Classification.Scope,
+		Breaking: analyzedCommit.Classification.Breaking,
+		Included: analyzedCommit.Included,
+		Reason:   analyzedCommit.Reason,
+	}
+	if analyzedCommit.ReleaseAs != nil {
+		commit.ReleaseAs = analyzedCommit.ReleaseAs.String()
+	}
+
+	return commit
+}
+
+func writeExplanationJSON(writer io.Writer, result nextversion.Result) error {
+	output := explanation{
+		Version:           result.VersionString(),
+		HasNextVersion:    result.HasNextVersion,
+		PreviousVersion:   result.FormatVersion(result.PreviousVersion),
+		PreReleaseChannel: result.PreReleaseChannel,
+		Snapshot:          result.Snapshot,
+		BaselineTag:       result.BaselineTag,
+		HeadCommit:        result.HeadCommit.String(),
+		Commits:           []explainedCommit{},
+		Reverts:           []explainedRevert{},
+	}
+	if !result.BaselineCommit.IsZero() {
+		output.BaselineCommit = result.BaselineCommit.String()
+	}
+	for _, analyzedCommit := range result.Commits {
+		output.Commits = append(output.Commits, toExplainedCommit(analyzedCommit))
+	}
+	if result.DeterminingCommit != nil {
+		determiningCommit := toExplainedCommit(*result.DeterminingCommit)
+		output.DeterminingCommit = &determiningCommit
+	}
+	for _, pair := range result.Reverts {
+		output.Reverts = append(output.Reverts, explainedRevert{Revert: pair.Revert.String(), Reverted: pair.Reverted.String()})
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+func writeExplanationText(writer io.Writer, result nextversion.Result) error {
+	if result.BaselineCommit.IsZero() {
+		fmt.Fprintf(writer, "Baseline:   none, using initial version %s\n", result.FormatVersion(result.PreviousVersion))
+	} else {
+		fmt.Fprintf(writer, "Baseline:   %s (%s) at %s\n", result.BaselineTag, result.FormatVersion(result.PreviousVersion), shortHash(result.BaselineCommit.String()))
+	}
+	fmt.Fprintf(writer, "Head:       %s\n", shortHash(result.HeadCommit.String()))
+	if result.PreReleaseChannel != "" {
+		fmt.Fprintf(writer, "Channel:    %s\n", result.PreReleaseChannel)
+	}
+	if result.Snapshot {
+		fmt.Fprintf(writer, "Snapshot:   %d commits since the baseline\n", len(result.Commits))
+	}
+	fmt.Fprintln(writer)
+
+	if len(result.Commits) == 0 {
+		fmt.Fprintf(writer, "No commits since the baseline.\n\n")
+	} else {
+		tableWriter := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tableWriter, "COMMIT\tTYPE\tSCOPE\tBREAKING\tSUBJECT\tREASON")
+		for _, analyzedCommit := range result.Commits {
+			commit := toExplainedCommit(analyzedCommit)
+			if !commit.Included {
+				commit.Type = "-"
+			}
+			fmt.Fprintf(tableWriter, "%s\t%s\t%s\t%v\t%s\t%s\n",
+				shortHash(commit.Hash), commit.Type, commit.Scope, commit.Breaking, commit.Subject, commit.Reason)
+		}
+		if err := tableWriter.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if len(result.Reverts) > 0 {
+		fmt.Fprintln(writer, "Cancelled reverts:")
+		for _, pair := range result.Reverts {
+			fmt.Fprintf(writer, "  %s reverts %s\n", shortHash(pair.Revert.String()), shortHash(pair.Reverted.String()))
+		}
+		fmt.Fprintln(writer)
+	}
+
+	if result.DeterminingCommit != nil {
+		determinedBy := result.DeterminingCommit.Classification.Type.String()
+		if result.DeterminingCommit.ReleaseAs != nil {
+			determinedBy = git.ReleaseAsFooterToken + ": " + result.DeterminingCommit.ReleaseAs.String()
+		}
+		fmt.Fprintf(writer, "Determined by: %s %s (%s)\n",
+			shortHash(result.DeterminingCommit.Hash.String()),
+			result.DeterminingCommit.Subject,
+			determinedBy)
+		fmt.Fprintf(writer, "Next version:  %s\n", result.VersionString())
+	} else {
+		fmt.Fprintf(writer, "No releasable changes, the version stays at %s\n", result.VersionString())
+	}
+
+	return nil
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
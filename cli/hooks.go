@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+const commitMsgHookMarker = "# installed by get-next-version"
+
+const commitMsgHook = `#!/bin/sh
+` + commitMsgHookMarker + `
+exec get-next-version lint --file "$1"
+`
+
+var hooksInstallForceFlag bool
+
+func init() {
+	HooksInstallCommand.Flags().BoolVar(&hooksInstallForceFlag, "force", false, "replaces an existing commit-msg hook")
+
+	HooksCommand.AddCommand(HooksInstallCommand)
+	RootCommand.AddCommand(HooksCommand)
+}
+
+var HooksCommand = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manages git hooks",
+	Long:  "Manages git hooks.",
+}
+
+var HooksInstallCommand = &cobra.Command{
+	Use:   "install",
+	Short: "Installs a commit-msg hook that lints commit messages",
+	Long:  "Installs a commit-msg hook that runs get-next-version lint on every commit message. The get-next-version executable must be on the PATH.",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		repository, err := gogit.PlainOpen(rootRepositoryFlag)
+		if err != nil {
+			return err
+		}
+
+		path, err := git.InstallHook(repository, "commit-msg", commitMsgHook, commitMsgHookMarker, hooksInstallForceFlag)
+		if errors.Is(err, git.ErrHookAlreadyExists) {
+			return fmt.Errorf("%w, use --force to replace it", err)
+		}
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("installed commit-msg hook at %s\n", path)
+		return nil
+	},
+}
@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+	"github.com/tvcsantos/get-next-version/config"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/lint"
+)
+
+var (
+	lintFileFlag   string
+	lintRangeFlag  string
+	lintOutputFlag string
+)
+
+func init() {
+	LintCommand.Flags().StringVar(&lintFileFlag, "file", "", "lints the commit message in the given file, or from stdin with -")
+	LintCommand.Flags().StringVar(&lintRangeFlag, "range", "", "lints every commit in the given revision range (e.g. main..HEAD)")
+	LintCommand.Flags().StringVarP(&lintOutputFlag, "output", "o", "text", "sets the output format (text or json)")
+	LintCommand.Flags().StringArray("allowed-scopes", nil, "allows scopes matching the glob or /regex/ (repeatable, defaults to any scope)")
+	LintCommand.Flags().Int("max-header-length", 0, "sets the maximum length of the header (defaults to no limit)")
+	LintCommand.Flags().String("required-footers", "", "sets the footers every commit message must have (comma-separated)")
+
+	RootCommand.AddCommand(LintCommand)
+}
+
+var LintCommand = &cobra.Command{
+	Use:   "lint",
+	Short: "Lints commit messages",
+	Long:  "Lints a commit message, or every commit in a revision range, against the configured commit types. Exits with status 1 if problems are found, or 2 if the lint could not run.",
+	RunE: func(command *cobra.Command, _ []string) error {
+		if (lintFileFlag == "") == (lintRangeFlag == "") {
+			return errors.New("exactly one of --file and --range is required")
+		}
+		if lintOutputFlag != "text" && lintOutputFlag != "json" {
+			return fmt.Errorf("invalid output format %+q", lintOutputFlag)
+		}
+
+		repository, resolved, err := openRepositoryWithConfig(command)
+		if err != nil {
+			return err
+		}
+
+		linter, err := createLinter(resolved.Config)
+		if err != nil {
+			return err
+		}
+
+		var results []lintResult
+		if lintFileFlag != "" {
+			results, err = lintMessageFile(linter, lintFileFlag)
+		} else {
+			results, err = lintCommitRange(command, repository, linter, lintRangeFlag)
+		}
+		if err != nil {
+			return err
+		}
+
+		if lintOutputFlag == "json" {
+			err = writeLintJSON(os.Stdout, results)
+		} else {
+			err = writeLintText(os.Stdout, results)
+		}
+		if err != nil {
+			return err
+		}
+
+		if problems := countProblems(results); problems > 0 {
+			return &LintProblemsError{Problems: problems}
+		}
+		return nil
+	},
+}
+
+// LintProblemsError is returned by the lint command when commit messages do
+// not pass the lint. Any other error means the lint could not run.
+type LintProblemsError struct {
+	Problems int
+}
+
+func (e *LintProblemsError) Error() string {
+	return fmt.Sprintf("found %d problems in commit messages", e.Problems)
+}
+
+type lintResult struct {
+	Source   string         `json:"source"`
+	Commit   string         `json:"commit,omitempty"`
+	Subject  string         `json:"subject"`
+	Findings []lint.Finding `json:"findings"`
+}
+
+func createLinter(cfg config.Config) (*lint.Linter, error) {
+	options := createOptions(cfg)
+	parser, err := options.CommitParser()
+	if err != nil {
+		return nil, err
+	}
+	classifier, err := options.TypeClassifier()
+	if err != nil {
+		return nil, err
+	}
+
+	return lint.NewLinter(lint.Options{
+		Parser:          parser,
+		Classifier:      classifier,
+		Scopes:          cfg.AllowedScopes,
+		MaxHeaderLength: cfg.MaxHeaderLength,
+		RequiredFooters: cfg.RequiredFooters,
+	})
+}
+
+func lintMessageFile(linter *lint.Linter, path string) ([]lintResult, error) {
+	var content []byte
+	var err error
+	if path == "-" {
+		content, err = io.ReadAll(os.Stdin)
+	} else {
+		content, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	message := lint.CleanMessage(string(content))
+	if lint.IsIgnored(message) {
+		return nil, nil
+	}
+
+	source := path
+	if path == "-" {
+		source = "stdin"
+	}
+	return []lintResult{{Source: source, Subject: subjectOf(message), Findings: linter.Lint(message)}}, nil
+}
+
+func lintCommitRange(command *cobra.Command, repository *gogit.Repository, linter *lint.Linter, revisionRange string) ([]lintResult, error) {
+	from, to, isRange := strings.Cut(revisionRange, "..")
+	if !isRange {
+		from, to = "", revisionRange
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	commits, err := git.GetCommitsInRange(command.Context(), repository, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	results := []lintResult{}
+	for _, commit := range commits {
+		if commit.NumParents() > 1 || lint.IsIgnored(commit.Message) {
+			continue
+		}
+		results = append(results, lintResult{
+			Source:   commit.Hash.String(),
+			Commit:   commit.Hash.String(),
+			Subject:  subjectOf(commit.Message),
+			Findings: linter.Lint(commit.Message),
+		})
+	}
+
+	return results, nil
+}
+
+func writeLintJSON(writer io.Writer, results []lintResult) error {
+	if results == nil {
+		results = []lintResult{}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Valid   bool         `json:"valid"`
+		Results []lintResult `json:"results"`
+	}{Valid: countProblems(results) == 0, Results: results})
+}
+
+func writeLintText(writer io.Writer, results []lintResult) error {
+	for _, result := range results {
+		if len(result.Findings) == 0 {
+			continue
+		}
+
+		source := result.Source
+		if result.Commit != "" {
+			source = shortHash(result.Commit)
+		}
+		fmt.Fprintf(writer, "%s %s\n", source, result.Subject)
+		for _, finding := range result.Findings {
+			fmt.Fprintf(writer, "  %s\n", finding)
+		}
+	}
+
+	return nil
+}
+
+func countProblems(results []lintResult) int {
+	problems := 0
+	for _, result := range results {
+		problems += len(result.Findings)
+	}
+	return problems
+}
+
+func subjectOf(message string) string {
+	return strings.TrimSpace(strings.SplitN(message, "\n", 2)[0])
+}
@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"os"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+	"github.com/tvcsantos/get-next-version/config"
+	"github.com/tvcsantos/get-next-version/nextversion"
+)
+
+func openRepositoryWithConfig(command *cobra.Command) (*gogit.Repository, config.Resolved, error) {
+	repository, err := gogit.PlainOpen(rootRepositoryFlag)
+	if err != nil {
+		return nil, config.Resolved{}, err
+	}
+
+	resolved, err := loadConfig(command, repository)
+	if err != nil {
+		return nil, config.Resolved{}, err
+	}
+
+	return repository, resolved, nil
+}
+
+func loadConfig(command *cobra.Command, repository *gogit.Repository) (config.Resolved, error) {
+	configPath := rootConfigFlag
+	if configPath == "" {
+		configPath = os.Getenv(config.EnvironmentVariable("config"))
+	}
+
+	if configPath == "" {
+		worktree, err := repository.Worktree()
+		if err != nil && err != gogit.ErrIsBareRepository {
+			return config.Resolved{}, err
+		}
+		if worktree != nil {
+			configPath, err = config.Discover(worktree.Filesystem.Root())
+			if err != nil {
+				return config.Resolved{}, err
+			}
+		}
+	}
+
+	var file *config.File
+	if configPath != "" {
+		var err error
+		file, err = config.LoadFile(configPath)
+		if err != nil {
+			return config.Resolved{}, err
+		}
+	}
+
+	return config.Resolve(file, os.LookupEnv, command.Flags())
+}
+
+func createOptions(cfg config.Config) nextversion.Options {
+	branches := make([]nextversion.BranchRule, len(cfg.Branches))
+	for i, branch := range cfg.Branches {
+		branches[i] = nextversion.BranchRule{
+			Pattern:    branch.Name,
+			Prerelease: branch.Prerelease,
+			Range:      branch.Range,
+		}
+	}
+
+	bumpRules := make([]nextversion.BumpRule, len(cfg.BumpRules))
+	for i, rule := range cfg.BumpRules {
+		bumpRules[i] = nextversion.BumpRule{
+			Type:     rule.Type,
+			Scope:    rule.Scope,
+			Breaking: rule.Breaking,
+			Bump:     rule.Bump,
+		}
+	}
+
+	return nextversion.Options{
+		Prefix:                        cfg.Prefix,
+		CommitConvention:              cfg.CommitConvention,
+		CommitPattern:                 cfg.CommitPattern,
+		FeaturePrefixes:               cfg.FeaturePrefixes,
+		FixPrefixes:                   cfg.FixPrefixes,
+		ChorePrefixes:                 cfg.ChorePrefixes,
+		BumpRules:                     bumpRules,
+		Strict:                        cfg.Strict,
+		NonConventionalAs:             cfg.NonConventionalAs,
+		TagsFilterRegex:               cfg.TagsFilterRegex,
+		VersionRegex:                  cfg.VersionRegex,
+		CommitsFilterPathRegex:        cfg.CommitsFilterPathRegex,
+		IncludeScopes:                 cfg.IncludeScopes,
+		ExcludeScopes:                 cfg.ExcludeScopes,
+		UnscopedCommits:               cfg.UnscopedCommits,
+		SkipAuthors:                   cfg.SkipAuthors,
+		SkipCommitters:                cfg.SkipCommitters,
+		SkipTrailers:                  cfg.SkipTrailers,
+		SkipMarkers:                   cfg.SkipMarkers,
+		InitialVersion:                cfg.InitialVersion,
+		BaselineStrategy:              cfg.BaselineStrategy,
+		OnVersionCollision:            cfg.OnVersionCollision,
+		VersionScheme:                 cfg.VersionScheme,
+		CalVerFormat:                  cfg.CalVerFormat,
+		InitialDevelopment:            cfg.InitialDevelopment,
+		InitialDevelopmentFeatureBump: cfg.InitialDevelopmentFeatureBump,
+		Graduate:                      cfg.Graduate,
+		Snapshot:                      cfg.Snapshot,
+		SnapshotIdentifier:            cfg.SnapshotIdentifier,
+		SnapshotMetadata:              cfg.SnapshotMetadata,
+		Prerelease:                    cfg.Prerelease,
+		Branch:                        cfg.Branch,
+		Branches:                      branches,
+		From:                          cfg.From,
+		To:                            cfg.To,
+		DeepenRemote:                  cfg.DeepenRemote,
+		DeepenAuth:                    pushTokenAuth(),
+	}
+}
+
+func createComponents(cfg config.Config) []nextversion.Component {
+	components := make([]nextversion.Component, len(cfg.Components))
+	for i, component := range cfg.Components {
+		components[i] = nextversion.Component{
+			Name:      component.Name,
+			Paths:     component.Paths,
+			TagPrefix: component.TagPrefix,
+		}
+	}
+
+	return components
+}
@@ -1,161 +1,179 @@
 package cli
 
 import (
-	"regexp"
-	"strings"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/template"
 
-	"github.com/Masterminds/semver"
 	gogit "github.com/go-git/go-git/v5"
-	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
-	"github.com/tvcsantos/get-next-version/conventionalcommits"
-	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/config"
+	"github.com/tvcsantos/get-next-version/nextversion"
 	"github.com/tvcsantos/get-next-version/target"
-	"github.com/tvcsantos/get-next-version/util"
-	"github.com/tvcsantos/get-next-version/versioning"
-	"golang.org/x/exp/slices"
 )
 
 var (
-	rootRepositoryFlag             string
-	rootTargetFlag                 string
-	rootPrefixFlag                 string
-	rootFeaturePrefixesFlag        string
-	rootFixPrefixesFlag            string
-	rootChorePrefixesFlag          string
-	rootTagsFilterRegexFlag        string
-	rootVersionRegex               string
-	rootCommitsFilterPathRegexFlag []string
-	rootInitialVersionFlag         string
+	rootRepositoryFlag string
+	rootConfigFlag     string
 )
 
 func init() {
-	RootCommand.Flags().StringVarP(&rootRepositoryFlag, "repository", "r", ".", "sets the path to the repository")
-	RootCommand.Flags().StringVarP(&rootTargetFlag, "target", "t", "version", "sets the output target")
-	RootCommand.Flags().StringVarP(&rootPrefixFlag, "prefix", "p", "", "sets the version prefix")
-	RootCommand.Flags().StringVar(&rootFeaturePrefixesFlag, "feature-prefixes", "", "sets custom feature prefixes (comma-separated)")
-	RootCommand.Flags().StringVar(&rootFixPrefixesFlag, "fix-prefixes", "", "sets custom fix prefixes (comma-separated)")
-	RootCommand.Flags().StringVar(&rootChorePrefixesFlag, "chore-prefixes", "", "sets custom chore prefixes (comma-separated)")
-	RootCommand.Flags().StringVarP(&rootTagsFilterRegexFlag, "tags-filter-regex", "f", "", "sets a regex to filter tags")
-	RootCommand.Flags().StringArrayVarP(&rootCommitsFilterPathRegexFlag, "commits-filter-path-regex", "c", nil, "sets a regex to filter commits by path")
-	RootCommand.Flags().StringVarP(&rootVersionRegex, "version-regex", "v", "", "sets a regex to extract the version from tags")
-	RootCommand.Flags().StringVarP(&rootInitialVersionFlag, "initial-version", "i", "", "sets the initial version to use if no previous version is found")
+	RootCommand.PersistentFlags().StringVarP(&rootRepositoryFlag, "repository", "r", ".", "sets the path to the repository")
+	RootCommand.PersistentFlags().StringVar(&rootConfigFlag, "config", "", "sets the path to the configuration file (defaults to .get-next-version.{yaml,yml,toml,json} at the repository root)")
+	RootCommand.PersistentFlags().StringP("target", "t", "version", "sets the output target")
+	RootCommand.Flags().String("template", "", "renders the output with the given Go template instead of the target")
+	RootCommand.Flags().String("template-file", "", "renders the output with the Go template in the given file instead of the target")
+	RootCommand.PersistentFlags().StringP("prefix", "p", "", "sets the version prefix")
+	RootCommand.PersistentFlags().String("commit-convention", "", "sets the commit message convention (conventional, gitmoji or regex, defaults to conventional)")
+	RootCommand.PersistentFlags().String("commit-pattern", "", "sets the regex with named groups type, scope, breaking and description for the regex commit convention")
+	RootCommand.PersistentFlags().String("feature-prefixes", "", "sets custom feature prefixes (comma-separated, cannot be combined with bump-rules)")
+	RootCommand.PersistentFlags().String("fix-prefixes", "", "sets custom fix prefixes (comma-separated, cannot be combined with bump-rules)")
+	RootCommand.PersistentFlags().String("chore-prefixes", "", "sets custom chore prefixes (comma-separated, cannot be combined with bump-rules)")
+	RootCommand.PersistentFlags().Bool("strict", false, "fails if a commit since the latest release is not a conventional commit")
+	RootCommand.PersistentFlags().String("non-conventional-as", "", "sets the version bump for commits that are not conventional commits (none, patch or minor, defaults to none)")
+	RootCommand.PersistentFlags().StringP("tags-filter-regex", "f", "", "sets a regex to filter tags")
+	RootCommand.PersistentFlags().StringArrayP("commits-filter-path-regex", "c", nil, "sets a regex to filter commits by path")
+	RootCommand.PersistentFlags().StringArray("include-scopes", nil, "only counts commits with a scope matching the glob or /regex/ (repeatable)")
+	RootCommand.PersistentFlags().StringArray("exclude-scopes", nil, "ignores commits whose scopes all match the glob or /regex/ (repeatable)")
+	RootCommand.PersistentFlags().String("unscoped-commits", "", "sets whether commits without scope are counted when filtering by scope (include or exclude, defaults to include)")
+	RootCommand.PersistentFlags().StringArray("skip-authors", nil, "skips commits whose author (name <email>) matches the regex")
+	RootCommand.PersistentFlags().StringArray("skip-committers", nil, "skips commits whose committer (name <email>) matches the regex")
+	RootCommand.PersistentFlags().String("skip-trailers", "", "skips commits with one of the trailers, as token or token: value (comma-separated)")
+	RootCommand.PersistentFlags().String("skip-markers", "", "skips commits whose subject contains one of the markers, e.g. [skip release] (comma-separated)")
+	RootCommand.PersistentFlags().StringP("version-regex", "v", "", "sets a regex to extract the version from tags")
+	RootCommand.PersistentFlags().StringP("initial-version", "i", "", "sets the initial version to use if no previous version is found")
+	RootCommand.PersistentFlags().String("baseline-strategy", "", "sets how the latest release is chosen among the tags reachable from HEAD (nearest or highest-reachable, defaults to nearest)")
+	RootCommand.PersistentFlags().String("on-version-collision", "", "sets what happens when the next version is already tagged (error or skip to the next free version, defaults to error)")
+	RootCommand.PersistentFlags().String("version-scheme", "", "sets the versioning scheme (semver or calver, defaults to semver)")
+	RootCommand.PersistentFlags().String("calver-format", "", "sets the format of calendar versions, e.g. YY.0M.MICRO (defaults to YYYY.MM.MICRO)")
+	RootCommand.PersistentFlags().Bool("initial-development", false, "applies initial development (0.x) rules while the major version is 0: breaking changes bump the minor version and features bump the patch version")
+	RootCommand.PersistentFlags().String("initial-development-feature-bump", "", "sets the version bump for features during initial development (patch or minor, defaults to patch)")
+	RootCommand.PersistentFlags().Bool("graduate", false, "releases 1.0.0 if the major version is 0")
+	RootCommand.PersistentFlags().Bool("snapshot", false, "computes a unique development version even without releasable changes (e.g. 1.4.0-dev.7+g3f2a9c1)")
+	RootCommand.PersistentFlags().String("snapshot-identifier", "", "sets the pre-release identifier of snapshot versions (defaults to dev)")
+	RootCommand.PersistentFlags().String("snapshot-metadata", "", "sets the build metadata of snapshot versions (hash, date and/or dirty, comma-separated)")
+	RootCommand.PersistentFlags().String("prerelease", "", "sets the pre-release channel (e.g. rc produces 2.0.0-rc.1, 2.0.0-rc.2, ...)")
+	RootCommand.PersistentFlags().String("branch", "", "sets the branch used to select branch rules (defaults to the checked out branch)")
+	RootCommand.PersistentFlags().String("from", "", "sets the revision to count commits from instead of the latest release (e.g. a hotfix branch point)")
+	RootCommand.PersistentFlags().String("to", "", "sets the revision to compute the next version for (defaults to HEAD)")
+	RootCommand.PersistentFlags().String("deepen-remote", "", "fetches more history and tags from the given remote when a shallow clone ends before the latest release, authenticating with "+pushTokenEnvironmentVariable+" if set")
+	RootCommand.Flags().Bool("create-tag", false, "creates the tag for the next version on HEAD (see the tag command)")
 }
 
 var RootCommand = &cobra.Command{
-	Use:   "get-next-version",
-	Short: "Get the next version according for semantic versioning",
-	Long:  "Get the next version according for semantic versioning.",
-	Run: func(_ *cobra.Command, _ []string) {
+	Use:           "get-next-version",
+	Short:         "Get the next version according for semantic versioning",
+	Long:          "Get the next version according for semantic versioning.",
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(command *cobra.Command, _ []string) error {
 		validTargets := []string{
 			"github-action",
 			"json",
 			"version",
 		}
 
-		if isValid, prefixValidationError := util.IsValidVersionPrefix(rootPrefixFlag); !isValid {
-			log.Fatal().Msgf("invalid version prefix %+q", prefixValidationError)
+		repository, resolved, err := openRepositoryWithConfig(command)
+		if err != nil {
+			return err
 		}
 
-		if !slices.Contains(validTargets, rootTargetFlag) {
-			log.Fatal().Msg("invalid target")
+		outputTemplate, err := loadOutputTemplate(resolved.Config)
+		if err != nil {
+			return err
 		}
 
-		classifier := createTypeClassifier()
-
-		repository, err := gogit.PlainOpen(rootRepositoryFlag)
-		if err != nil {
-			log.Fatal().Msg(err.Error())
+		if outputTemplate == nil && !slices.Contains(validTargets, resolved.Config.Target) {
+			return errors.New("invalid target")
 		}
 
-		var nextVersion semver.Version
-		var hasNextVersion bool
-		var versionRegex *regexp.Regexp
-		var commitsFilterPathRegex []util.PathFilterRegex
-		var tagsFilterRegex *regexp.Regexp
-		var initialVersion *semver.Version
-
-		if rootVersionRegex != "" {
-			versionRegex, err = regexp.Compile(rootVersionRegex)
-			if err != nil {
-				log.Fatal().Err(err).Msgf("invalid version regex: %s", rootVersionRegex)
-			}
+		if resolved.Config.CreateTag && resolved.Config.Snapshot {
+			return errors.New("--create-tag and --snapshot are mutually exclusive")
 		}
-		if rootTagsFilterRegexFlag != "" {
-			tagsFilterRegex, err = regexp.Compile(rootTagsFilterRegexFlag)
-			if err != nil {
-				log.Fatal().Err(err).Msgf("invalid tags filter regex: %s", rootTagsFilterRegexFlag)
+
+		if len(resolved.Config.Components) > 0 {
+			if outputTemplate != nil {
+				return errors.New("templates are not supported with components")
 			}
+			return runComponents(command.Context(), repository, resolved.Config)
 		}
-		if len(commitsFilterPathRegex) > 0 {
-			commitsFilterPathRegex = make([]util.PathFilterRegex, len(rootCommitsFilterPathRegexFlag))
-			for i, regexStr := range rootCommitsFilterPathRegexFlag {
-				commitsFilterPathRegex[i], err = util.ToPathRegex(regexStr)
-				if err != nil {
-					log.Fatal().Err(err).Msgf("invalid commits filter path regex: %s", regexStr)
-				}
-			}
+
+		result, err := nextversion.Compute(command.Context(), repository, createOptions(resolved.Config))
+		if err != nil {
+			return err
 		}
-		if rootInitialVersionFlag != "" {
-			initialVersion, err = semver.NewVersion(rootInitialVersionFlag)
-			if err != nil {
-				log.Fatal().Err(err).Msgf("invalid initial version: %s", rootInitialVersionFlag)
-			}
+
+		if outputTemplate != nil {
+			err = target.RenderTemplate(os.Stdout, outputTemplate, target.NewTemplateData(result))
 		} else {
-			initialVersion = semver.MustParse("0.0.0")
+			err = target.WriteVersionStringOutput(result.VersionString(), result.HasNextVersion, resolved.Config.Target)
 		}
-
-		result, err := git.GetConventionalCommitTypesSinceLastRelease(
-			repository,
-			classifier,
-			commitsFilterPathRegex,
-			tagsFilterRegex,
-			versionRegex,
-			initialVersion,
-		)
-
 		if err != nil {
-			log.Fatal().Msg(err.Error())
-		} else {
-			nextVersion, hasNextVersion = versioning.CalculateNextVersion(result.LatestReleaseVersion, result.ConventionalCommitTypes)
+			return fmt.Errorf("could not write output: %w", err)
 		}
 
-		err = target.WriteOutput(nextVersion, hasNextVersion, rootTargetFlag, rootPrefixFlag)
-		if err != nil {
-			log.Fatal().Err(err).Msg("could not write output")
+		if resolved.Config.CreateTag && result.HasNextVersion {
+			return createReleaseTag(command.Context(), repository, result, resolved.Config)
 		}
+
+		return nil
 	},
 }
 
-func createTypeClassifier() *conventionalcommits.TypeClassifier {
-	var choreTypes, fixTypes, featureTypes []string
-
-	if rootChorePrefixesFlag != "" {
-		choreTypes = parseCommaSeparatedPrefixes(rootChorePrefixesFlag)
+func loadOutputTemplate(cfg config.Config) (*template.Template, error) {
+	switch {
+	case cfg.Template != "" && cfg.TemplateFile != "":
+		return nil, errors.New("--template and --template-file are mutually exclusive")
+	case cfg.Template != "":
+		return target.ParseTemplate("template", cfg.Template)
+	case cfg.TemplateFile != "":
+		text, err := os.ReadFile(cfg.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read template file: %w", err)
+		}
+		return target.ParseTemplate(filepath.Base(cfg.TemplateFile), string(text))
+	default:
+		return nil, nil
 	}
+}
 
-	if rootFixPrefixesFlag != "" {
-		fixTypes = parseCommaSeparatedPrefixes(rootFixPrefixesFlag)
+func runComponents(ctx context.Context, repository *gogit.Repository, cfg config.Config) error {
+	results, err := nextversion.ComputeComponents(ctx, repository, createOptions(cfg), createComponents(cfg))
+	if err != nil {
+		return err
 	}
 
-	if rootFeaturePrefixesFlag != "" {
-		featureTypes = parseCommaSeparatedPrefixes(rootFeaturePrefixesFlag)
+	componentVersions := make([]target.ComponentVersion, len(results))
+	for i, result := range results {
+		componentVersions[i] = target.ComponentVersion{
+			Name:           result.Name,
+			Version:        *result.Version,
+			HasNextVersion: result.HasNextVersion,
+			Prefix:         result.Prefix,
+			VersionString:  result.VersionString(),
+		}
 	}
 
-	return conventionalcommits.NewTypeClassifierWithCustomPrefixes(choreTypes, fixTypes, featureTypes)
-}
+	err = target.WriteComponentsOutput(componentVersions, cfg.Target)
+	if err != nil {
+		return fmt.Errorf("could not write output: %w", err)
+	}
 
-func parseCommaSeparatedPrefixes(input string) []string {
-	if input == "" {
+	if !cfg.CreateTag {
 		return nil
 	}
-
-	var result []string
-	for _, prefix := range strings.Split(input, ",") {
-		trimmed := strings.TrimSpace(prefix)
-		if trimmed != "" {
-			result = append(result, trimmed)
+	for _, result := range results {
+		if !result.HasNextVersion {
+			continue
+		}
+		if err := createReleaseTag(ctx, repository, result.Result, cfg); err != nil {
+			return err
 		}
 	}
-	return result
+
+	return nil
 }
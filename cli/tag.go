@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/spf13/cobra"
+	"github.com/tvcsantos/get-next-version/config"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/nextversion"
+)
+
+const (
+	signingKeyPassphraseEnvironmentVariable = config.EnvironmentPrefix + "SIGNING_KEY_PASSPHRASE"
+	pushTokenEnvironmentVariable            = config.EnvironmentPrefix + "PUSH_TOKEN"
+)
+
+func init() {
+	RootCommand.PersistentFlags().Bool("tag-annotated", false, "creates an annotated tag instead of a lightweight one")
+	RootCommand.PersistentFlags().String("tag-message", "", "sets the tag message template (implies --tag-annotated, defaults to \""+nextversion.DefaultTagMessage+"\")")
+	RootCommand.PersistentFlags().String("tagger-name", "", "sets the tagger name (defaults to user.name from git config)")
+	RootCommand.PersistentFlags().String("tagger-email", "", "sets the tagger email (defaults to user.email from git config)")
+	RootCommand.PersistentFlags().String("tag-sign", "", "signs the tag (gpg or ssh), the key passphrase is read from "+signingKeyPassphraseEnvironmentVariable)
+	RootCommand.PersistentFlags().String("tag-signing-key", "", "sets the path to the armored GPG private key or SSH private key used for signing")
+	RootCommand.PersistentFlags().String("tag-remote", "", "pushes the tag to the given remote, authenticating with "+pushTokenEnvironmentVariable+" if set")
+
+	RootCommand.AddCommand(TagCommand)
+}
+
+var TagCommand = &cobra.Command{
+	Use:   "tag",
+	Short: "Creates the tag for the next version",
+	Long:  "Creates the tag for the next version on HEAD and optionally signs and pushes it.",
+	RunE: func(command *cobra.Command, _ []string) error {
+		repository, resolved, err := openRepositoryWithConfig(command)
+		if err != nil {
+			return err
+		}
+
+		result, err := nextversion.Compute(command.Context(), repository, createOptions(resolved.Config))
+		if err != nil {
+			return err
+		}
+
+		if !result.HasNextVersion {
+			fmt.Println("no next version, no tag created")
+			return nil
+		}
+
+		if err := createReleaseTag(command.Context(), repository, result, resolved.Config); err != nil {
+			return err
+		}
+
+		fmt.Println(result.VersionString())
+		return nil
+	},
+}
+
+func createReleaseTag(ctx context.Context, repository *gogit.Repository, result nextversion.Result, cfg config.Config) error {
+	options, err := createTagOptions(cfg)
+	if err != nil {
+		return err
+	}
+
+	_, err = nextversion.CreateReleaseTag(ctx, repository, result, options)
+	if err != nil {
+		return fmt.Errorf("could not create tag: %w", err)
+	}
+
+	return nil
+}
+
+func createTagOptions(cfg config.Config) (nextversion.TagOptions, error) {
+	options := nextversion.TagOptions{
+		Annotated:   cfg.TagAnnotated,
+		Message:     cfg.TagMessage,
+		TaggerName:  cfg.TaggerName,
+		TaggerEmail: cfg.TaggerEmail,
+		Remote:      cfg.TagRemote,
+	}
+
+	if cfg.TagSign != "" && cfg.TagSigningKey == "" {
+		return nextversion.TagOptions{}, fmt.Errorf("--tag-sign requires --tag-signing-key")
+	}
+
+	passphrase := os.Getenv(signingKeyPassphraseEnvironmentVariable)
+	switch cfg.TagSign {
+	case "":
+	case "gpg":
+		signer, err := git.LoadGPGSigner(cfg.TagSigningKey, passphrase)
+		if err != nil {
+			return nextversion.TagOptions{}, err
+		}
+		options.Signer = signer
+	case "ssh":
+		signer, err := git.LoadSSHSigner(cfg.TagSigningKey, passphrase)
+		if err != nil {
+			return nextversion.TagOptions{}, err
+		}
+		options.Signer = signer
+	default:
+		return nextversion.TagOptions{}, fmt.Errorf("invalid signing method %+q", cfg.TagSign)
+	}
+
+	options.Auth = pushTokenAuth()
+
+	return options, nil
+}
+
+// pushTokenAuth authenticates with the remote using the push token, if set.
+func pushTokenAuth() transport.AuthMethod {
+	if token := os.Getenv(pushTokenEnvironmentVariable); token != "" {
+		return &http.BasicAuth{Username: "get-next-version", Password: token}
+	}
+
+	return nil
+}
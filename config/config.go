@@ -0,0 +1,87 @@
+package config
+
+type Config struct {
+	Prefix                        string             `json:"prefix"`
+	CommitConvention              string             `json:"commit-convention"`
+	CommitPattern                 string             `json:"commit-pattern"`
+	FeaturePrefixes               []string           `json:"feature-prefixes"`
+	FixPrefixes                   []string           `json:"fix-prefixes"`
+	ChorePrefixes                 []string           `json:"chore-prefixes"`
+	BumpRules                     []BumpRule         `json:"bump-rules"`
+	Strict                        bool               `json:"strict"`
+	NonConventionalAs             string             `json:"non-conventional-as"`
+	TagsFilterRegex               string             `json:"tags-filter-regex"`
+	CommitsFilterPathRegex        []string           `json:"commits-filter-path-regex" list:"lines"`
+	IncludeScopes                 []string           `json:"include-scopes" list:"lines"`
+	ExcludeScopes                 []string           `json:"exclude-scopes" list:"lines"`
+	UnscopedCommits               string             `json:"unscoped-commits"`
+	SkipAuthors                   []string           `json:"skip-authors" list:"lines"`
+	SkipCommitters                []string           `json:"skip-committers" list:"lines"`
+	SkipTrailers                  []string           `json:"skip-trailers"`
+	SkipMarkers                   []string           `json:"skip-markers"`
+	VersionRegex                  string             `json:"version-regex"`
+	InitialVersion                string             `json:"initial-version"`
+	BaselineStrategy              string             `json:"baseline-strategy"`
+	OnVersionCollision            string             `json:"on-version-collision"`
+	VersionScheme                 string             `json:"version-scheme"`
+	CalVerFormat                  string             `json:"calver-format"`
+	InitialDevelopment            bool               `json:"initial-development"`
+	InitialDevelopmentFeatureBump string             `json:"initial-development-feature-bump"`
+	Graduate                      bool               `json:"graduate"`
+	Snapshot                      bool               `json:"snapshot"`
+	SnapshotIdentifier            string             `json:"snapshot-identifier"`
+	SnapshotMetadata              []string           `json:"snapshot-metadata"`
+	Target                        string             `json:"target"`
+	Template                      string             `json:"template"`
+	TemplateFile                  string             `json:"template-file"`
+	Prerelease                    string             `json:"prerelease"`
+	Branch                        string             `json:"branch"`
+	Branches                      []Branch           `json:"branches"`
+	From                          string             `json:"from"`
+	To                            string             `json:"to"`
+	DeepenRemote                  string             `json:"deepen-remote"`
+	ChangelogSections             []ChangelogSection `json:"changelog-sections"`
+	Components                    []Component        `json:"components"`
+	CreateTag                     bool               `json:"create-tag"`
+	TagAnnotated                  bool               `json:"tag-annotated"`
+	TagMessage                    string             `json:"tag-message"`
+	TaggerName                    string             `json:"tagger-name"`
+	TaggerEmail                   string             `json:"tagger-email"`
+	TagSign                       string             `json:"tag-sign"`
+	TagSigningKey                 string             `json:"tag-signing-key"`
+	TagRemote                     string             `json:"tag-remote"`
+	AllowedScopes                 []string           `json:"allowed-scopes" list:"lines"`
+	MaxHeaderLength               int                `json:"max-header-length"`
+	RequiredFooters               []string           `json:"required-footers"`
+}
+
+type BumpRule struct {
+	Type     string `json:"type"`
+	Scope    string `json:"scope"`
+	Breaking bool   `json:"breaking"`
+	Bump     string `json:"bump"`
+}
+
+type Branch struct {
+	Name       string `json:"name"`
+	Prerelease string `json:"prerelease"`
+	Range      string `json:"range"`
+}
+
+type Component struct {
+	Name      string   `json:"name"`
+	Paths     []string `json:"paths"`
+	TagPrefix string   `json:"tag-prefix"`
+}
+
+type ChangelogSection struct {
+	Title    string   `json:"title"`
+	Types    []string `json:"types"`
+	Breaking bool     `json:"breaking"`
+}
+
+func Default() Config {
+	return Config{
+		Target: "version",
+	}
+}
@@ -0,0 +1,95 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+var FileNames = []string{
+	".get-next-version.yaml",
+	".get-next-version.yml",
+	".get-next-version.toml",
+	".get-next-version.json",
+}
+
+type File struct {
+	Path   string
+	Config Config
+	keys   map[string]bool
+}
+
+func (f *File) Has(key string) bool {
+	if f == nil {
+		return false
+	}
+	return f.keys[key]
+}
+
+func Discover(directory string) (string, error) {
+	for _, fileName := range FileNames {
+		path := filepath.Join(directory, fileName)
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() {
+			return path, nil
+		}
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+	}
+
+	return "", nil
+}
+
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	var values map[string]any
+	switch filepath.Ext(path) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	case ".json":
+		err = json.Unmarshal(data, &values)
+	default:
+		return nil, fmt.Errorf("unsupported config file format: %s", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	// All formats are normalized through JSON, so the Config struct only has
+	// to carry a single set of field tags.
+	normalized, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+
+	var config Config
+	decoder := json.NewDecoder(bytes.NewReader(normalized))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	keys := make(map[string]bool, len(values))
+	for key := range values {
+		keys[key] = true
+	}
+
+	return &File{
+		Path:   path,
+		Config: config,
+		keys:   keys,
+	}, nil
+}
@@ -0,0 +1,158 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/config"
+)
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		fileName       string
+		content        string
+		doExpectError  bool
+		expectedConfig config.Config
+		expectedKeys   []string
+	}{
+		{
+			fileName:       ".get-next-version.yaml",
+			content:        "prefix: v\nfix-prefixes:\n  - fix\n  - deps\n",
+			expectedConfig: config.Config{Prefix: "v", FixPrefixes: []string{"fix", "deps"}},
+			expectedKeys:   []string{"prefix", "fix-prefixes"},
+		},
+		{
+			fileName:       ".get-next-version.yml",
+			content:        "tags-filter-regex: 'component-.*'\n",
+			expectedConfig: config.Config{TagsFilterRegex: "component-.*"},
+			expectedKeys:   []string{"tags-filter-regex"},
+		},
+		{
+			fileName:       ".get-next-version.toml",
+			content:        "prefix = \"v\"\ninitial-version = \"1.0.0\"\n",
+			expectedConfig: config.Config{Prefix: "v", InitialVersion: "1.0.0"},
+			expectedKeys:   []string{"prefix", "initial-version"},
+		},
+		{
+			fileName:       ".get-next-version.json",
+			content:        `{"target": "json", "commits-filter-path-regex": ["^src/"]}`,
+			expectedConfig: config.Config{Target: "json", CommitsFilterPathRegex: []string{"^src/"}},
+			expectedKeys:   []string{"target", "commits-filter-path-regex"},
+		},
+		{
+			fileName:       ".get-next-version.yaml",
+			content:        "prerelease: rc\nbranches:\n  - name: main\n  - name: beta/*\n    prerelease: beta\n",
+			expectedConfig: config.Config{Prerelease: "rc", Branches: []config.Branch{{Name: "main"}, {Name: "beta/*", Prerelease: "beta"}}},
+			expectedKeys:   []string{"prerelease", "branches"},
+		},
+		{
+			fileName:       ".get-next-version.toml",
+			content:        "[[branches]]\nname = \"next\"\nprerelease = \"rc\"\n",
+			expectedConfig: config.Config{Branches: []config.Branch{{Name: "next", Prerelease: "rc"}}},
+			expectedKeys:   []string{"branches"},
+		},
+		{
+			fileName:       ".get-next-version.yaml",
+			content:        "branches:\n  - name: main\n  - name: release/1.x\n    range: 1.x\n",
+			expectedConfig: config.Config{Branches: []config.Branch{{Name: "main"}, {Name: "release/1.x", Range: "1.x"}}},
+			expectedKeys:   []string{"branches"},
+		},
+		{
+			fileName: ".get-next-version.yaml",
+			content:  "fix-prefixes: [fix, perf]\nchangelog-sections:\n  - title: Breaking Changes\n    breaking: true\n  - title: Fixes\n    types: [fix, perf]\n",
+			expectedConfig: config.Config{
+				FixPrefixes: []string{"fix", "perf"},
+				ChangelogSections: []config.ChangelogSection{
+					{Title: "Breaking Changes", Breaking: true},
+					{Title: "Fixes", Types: []string{"fix", "perf"}},
+				},
+			},
+			expectedKeys: []string{"fix-prefixes", "changelog-sections"},
+		},
+		{
+			fileName: ".get-next-version.toml",
+			content:  "[[components]]\nname = \"foo\"\npaths = [\"packages/foo/**\"]\ntag-prefix = \"pkg/foo/v\"\n",
+			expectedConfig: config.Config{
+				Components: []config.Component{
+					{Name: "foo", Paths: []string{"packages/foo/**"}, TagPrefix: "pkg/foo/v"},
+				},
+			},
+			expectedKeys: []string{"components"},
+		},
+		{
+			fileName: ".get-next-version.yaml",
+			content:  "bump-rules:\n  - type: deps\n    scope: runtime\n    bump: patch\n  - type: docs\n    breaking: true\n    bump: none\n",
+			expectedConfig: config.Config{
+				BumpRules: []config.BumpRule{
+					{Type: "deps", Scope: "runtime", Bump: "patch"},
+					{Type: "docs", Breaking: true, Bump: "none"},
+				},
+			},
+			expectedKeys: []string{"bump-rules"},
+		},
+		{
+			fileName:       ".get-next-version.yaml",
+			content:        "",
+			expectedConfig: config.Config{},
+			expectedKeys:   []string{},
+		},
+		{
+			fileName:      ".get-next-version.yaml",
+			content:       "unknown-key: true\n",
+			doExpectError: true,
+		},
+		{
+			fileName:      ".get-next-version.json",
+			content:       `{"prefix": `,
+			doExpectError: true,
+		},
+		{
+			fileName:      ".get-next-version.ini",
+			content:       "prefix=v",
+			doExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.fileName, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), test.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(test.content), 0644))
+
+			file, err := config.LoadFile(path)
+
+			if test.doExpectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, path, file.Path)
+			assert.Equal(t, test.expectedConfig, file.Config)
+			for _, key := range test.expectedKeys {
+				assert.True(t, file.Has(key), key)
+			}
+			assert.False(t, file.Has("version-regex"))
+		})
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	directory := t.TempDir()
+
+	path, err := config.Discover(directory)
+	assert.NoError(t, err)
+	assert.Equal(t, "", path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(directory, ".get-next-version.json"), []byte("{}"), 0644))
+	path, err = config.Discover(directory)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(directory, ".get-next-version.json"), path)
+
+	require.NoError(t, os.WriteFile(filepath.Join(directory, ".get-next-version.yaml"), []byte(""), 0644))
+	path, err = config.Discover(directory)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(directory, ".get-next-version.yaml"), path)
+}
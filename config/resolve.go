@@ -0,0 +1,185 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+type Source string
+
+const (
+	SourceDefault     Source = "default"
+	SourceFile        Source = "file"
+	SourceEnvironment Source = "environment"
+	SourceFlag        Source = "flag"
+)
+
+const EnvironmentPrefix = "GNV_"
+
+type Resolved struct {
+	Config  Config
+	Sources map[string]Source
+	File    string
+}
+
+type Setting struct {
+	Key    string
+	Value  any
+	Source Source
+}
+
+func EnvironmentVariable(key string) string {
+	return EnvironmentPrefix + strings.ToUpper(strings.ReplaceAll(key, "-", "_"))
+}
+
+/*
+Resolve merges the configuration sources into the effective configuration.
+Precedence, from highest to lowest:
+  - command line flags
+  - GNV_* environment variables
+  - the configuration file
+  - built-in defaults
+*/
+func Resolve(file *File, lookupEnv func(string) (string, bool), flags *pflag.FlagSet) (Resolved, error) {
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+
+	resolved := Resolved{
+		Config:  Default(),
+		Sources: map[string]Source{},
+	}
+	if file != nil {
+		resolved.File = file.Path
+	}
+
+	target := reflect.ValueOf(&resolved.Config).Elem()
+	var fileValues reflect.Value
+	if file != nil {
+		fileValues = reflect.ValueOf(file.Config)
+	}
+
+	for i := 0; i < target.NumField(); i++ {
+		structField := target.Type().Field(i)
+		key := fieldKey(structField)
+		field := target.Field(i)
+		resolved.Sources[key] = SourceDefault
+
+		if file.Has(key) {
+			field.Set(fileValues.Field(i))
+			resolved.Sources[key] = SourceFile
+		}
+
+		if !isOverridable(field) {
+			continue
+		}
+
+		if value, ok := lookupEnv(EnvironmentVariable(key)); ok {
+			if err := setFromString(field, value, listSeparator(structField)); err != nil {
+				return Resolved{}, fmt.Errorf("invalid value for environment variable %s: %w", EnvironmentVariable(key), err)
+			}
+			resolved.Sources[key] = SourceEnvironment
+		}
+
+		if flags != nil && flags.Changed(key) {
+			if err := setFromFlag(field, flags.Lookup(key), listSeparator(structField)); err != nil {
+				return Resolved{}, fmt.Errorf("invalid value for flag --%s: %w", key, err)
+			}
+			resolved.Sources[key] = SourceFlag
+		}
+	}
+
+	return resolved, nil
+}
+
+func (r Resolved) Settings() []Setting {
+	value := reflect.ValueOf(r.Config)
+
+	settings := make([]Setting, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		key := fieldKey(value.Type().Field(i))
+		settings = append(settings, Setting{
+			Key:    key,
+			Value:  value.Field(i).Interface(),
+			Source: r.Sources[key],
+		})
+	}
+
+	return settings
+}
+
+func fieldKey(field reflect.StructField) string {
+	return strings.Split(field.Tag.Get("json"), ",")[0]
+}
+
+// listSeparator separates the items of a list in a single string. Lists of
+// regular expressions, tagged list:"lines", are newline-separated, as the
+// expressions may contain commas.
+func listSeparator(field reflect.StructField) string {
+	if field.Tag.Get("list") == "lines" {
+		return "\n"
+	}
+	return ","
+}
+
+func isOverridable(field reflect.Value) bool {
+	switch field.Kind() {
+	case reflect.String, reflect.Bool, reflect.Int:
+		return true
+	case reflect.Slice:
+		return field.Type().Elem().Kind() == reflect.String
+	default:
+		return false
+	}
+}
+
+func setFromString(field reflect.Value, value string, separator string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int:
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(parsed))
+	case reflect.Slice:
+		field.Set(reflect.ValueOf(splitList(value, separator)))
+	}
+
+	return nil
+}
+
+func setFromFlag(field reflect.Value, flag *pflag.Flag, separator string) error {
+	if field.Kind() == reflect.Slice {
+		switch values := flag.Value.(type) {
+		case pflag.SliceValue:
+			field.Set(reflect.ValueOf(values.GetSlice()))
+			return nil
+		}
+	}
+
+	return setFromString(field, flag.Value.String(), separator)
+}
+
+func splitList(input string, separator string) []string {
+	var result []string
+	for _, item := range strings.Split(input, separator) {
+		trimmed := strings.TrimSpace(item)
+		if trimmed != "" {
+			result = append(result, trimmed)
+		}
+	}
+	return result
+}
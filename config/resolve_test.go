@@ -0,0 +1,139 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/config"
+)
+
+func createFlags(t *testing.T, arguments []string) *pflag.FlagSet {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	flags.String("prefix", "", "")
+	flags.String("fix-prefixes", "", "")
+	flags.StringArray("commits-filter-path-regex", nil, "")
+	flags.StringArray("include-scopes", nil, "")
+	flags.String("target", "version", "")
+	require.NoError(t, flags.Parse(arguments))
+	return flags
+}
+
+func createLookupEnv(environment map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := environment[key]
+		return value, ok
+	}
+}
+
+func TestResolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".get-next-version.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("prefix: file-\nfix-prefixes: [fix, deps]\ntags-filter-regex: '^v'\n"), 0644))
+	file, err := config.LoadFile(path)
+	require.NoError(t, err)
+
+	t.Run("uses defaults without any source", func(t *testing.T) {
+		resolved, err := config.Resolve(nil, createLookupEnv(nil), createFlags(t, nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, config.Default(), resolved.Config)
+		assert.Equal(t, "", resolved.File)
+		assert.Equal(t, config.SourceDefault, resolved.Sources["prefix"])
+		assert.Equal(t, config.SourceDefault, resolved.Sources["target"])
+	})
+
+	t.Run("file overrides defaults", func(t *testing.T) {
+		resolved, err := config.Resolve(file, createLookupEnv(nil), createFlags(t, nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, path, resolved.File)
+		assert.Equal(t, "file-", resolved.Config.Prefix)
+		assert.Equal(t, []string{"fix", "deps"}, resolved.Config.FixPrefixes)
+		assert.Equal(t, "version", resolved.Config.Target)
+		assert.Equal(t, config.SourceFile, resolved.Sources["prefix"])
+		assert.Equal(t, config.SourceDefault, resolved.Sources["target"])
+	})
+
+	t.Run("environment overrides file", func(t *testing.T) {
+		resolved, err := config.Resolve(file, createLookupEnv(map[string]string{
+			"GNV_PREFIX":       "env-",
+			"GNV_FIX_PREFIXES": "fix, perf",
+		}), createFlags(t, nil))
+		require.NoError(t, err)
+
+		assert.Equal(t, "env-", resolved.Config.Prefix)
+		assert.Equal(t, []string{"fix", "perf"}, resolved.Config.FixPrefixes)
+		assert.Equal(t, "^v", resolved.Config.TagsFilterRegex)
+		assert.Equal(t, config.SourceEnvironment, resolved.Sources["prefix"])
+		assert.Equal(t, config.SourceFile, resolved.Sources["tags-filter-regex"])
+	})
+
+	t.Run("flags override environment", func(t *testing.T) {
+		resolved, err := config.Resolve(
+			file,
+			createLookupEnv(map[string]string{"GNV_PREFIX": "env-"}),
+			createFlags(t, []string{"--prefix", "flag-", "--fix-prefixes", "fix,build", "--commits-filter-path-regex", "^src/", "--commits-filter-path-regex", "!^docs/", "--include-scopes", "api", "--include-scopes", "/^v{1,2}$/"}),
+		)
+		require.NoError(t, err)
+
+		assert.Equal(t, "flag-", resolved.Config.Prefix)
+		assert.Equal(t, []string{"fix", "build"}, resolved.Config.FixPrefixes)
+		assert.Equal(t, []string{"^src/", "!^docs/"}, resolved.Config.CommitsFilterPathRegex)
+		assert.Equal(t, []string{"api", "/^v{1,2}$/"}, resolved.Config.IncludeScopes)
+		assert.Equal(t, config.SourceFlag, resolved.Sources["prefix"])
+		assert.Equal(t, config.SourceFlag, resolved.Sources["commits-filter-path-regex"])
+	})
+
+	t.Run("parses boolean settings", func(t *testing.T) {
+		resolved, err := config.Resolve(nil, createLookupEnv(map[string]string{"GNV_CREATE_TAG": "true"}), nil)
+		require.NoError(t, err)
+		assert.True(t, resolved.Config.CreateTag)
+		assert.Equal(t, config.SourceEnvironment, resolved.Sources["create-tag"])
+
+		_, err = config.Resolve(nil, createLookupEnv(map[string]string{"GNV_CREATE_TAG": "maybe"}), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("parses integer settings", func(t *testing.T) {
+		resolved, err := config.Resolve(nil, createLookupEnv(map[string]string{"GNV_MAX_HEADER_LENGTH": "72"}), nil)
+		require.NoError(t, err)
+		assert.Equal(t, 72, resolved.Config.MaxHeaderLength)
+
+		_, err = config.Resolve(nil, createLookupEnv(map[string]string{"GNV_MAX_HEADER_LENGTH": "long"}), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("splits regular expression lists on newlines", func(t *testing.T) {
+		resolved, err := config.Resolve(nil, createLookupEnv(map[string]string{
+			"GNV_COMMITS_FILTER_PATH_REGEX": `^src/a{1,2}\.go$`,
+			"GNV_SKIP_AUTHORS":              "^bot,\n\\[bot\\]\n",
+			"GNV_INCLUDE_SCOPES":            "api\n/^v{1,2}$/",
+			"GNV_EXCLUDE_SCOPES":            "/^(web|docs),/",
+			"GNV_FIX_PREFIXES":              "fix,perf",
+		}), nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{`^src/a{1,2}\.go$`}, resolved.Config.CommitsFilterPathRegex)
+		assert.Equal(t, []string{"^bot,", `\[bot\]`}, resolved.Config.SkipAuthors)
+		assert.Equal(t, []string{"api", "/^v{1,2}$/"}, resolved.Config.IncludeScopes)
+		assert.Equal(t, []string{"/^(web|docs),/"}, resolved.Config.ExcludeScopes)
+		assert.Equal(t, []string{"fix", "perf"}, resolved.Config.FixPrefixes)
+	})
+
+	t.Run("lists settings in declaration order", func(t *testing.T) {
+		resolved, err := config.Resolve(file, createLookupEnv(nil), nil)
+		require.NoError(t, err)
+
+		settings := resolved.Settings()
+		require.NotEmpty(t, settings)
+		assert.Equal(t, config.Setting{Key: "prefix", Value: "file-", Source: config.SourceFile}, settings[0])
+	})
+}
+
+func TestEnvironmentVariable(t *testing.T) {
+	assert.Equal(t, "GNV_PREFIX", config.EnvironmentVariable("prefix"))
+	assert.Equal(t, "GNV_TAGS_FILTER_REGEX", config.EnvironmentVariable("tags-filter-regex"))
+}
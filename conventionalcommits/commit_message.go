@@ -3,87 +3,99 @@ package conventionalcommits
 import (
 	"errors"
 	"fmt"
-	"regexp"
 	"strings"
-
-	"github.com/tvcsantos/get-next-version/util"
 )
 
-var (
-	breakingFooterTokens  = []string{"BREAKING CHANGE", "BREAKING-CHANGE"}
-	footerTokenSeparators = []string{": ", " #"}
-)
+var errInvalidHeader = errors.New("invalid message body for conventional commit message")
 
-func createBodyRegex(classifier *TypeClassifier) *regexp.Regexp {
-	typesRegexString := ""
-	for _, prefix := range classifier.GetAllTypes() {
-		typesRegexString += prefix + "|"
+type Classification struct {
+	Type        Type
+	CommitType  string
+	Scope       string
+	Breaking    bool
+	Description string
+	Reason      string
+}
+
+func findBreakingFooter(footers []Footer) (Footer, bool) {
+	for _, footer := range footers {
+		if isBreakingFooterToken(footer.Token) {
+			return footer, true
+		}
 	}
-	typesRegexString = strings.TrimSuffix(typesRegexString, "|")
-	conventionalCommitBodyRegexString := fmt.Sprintf(
-		"(?P<type>(?i:%s))(\\(.*\\))?(?P<breaking>\\!)?:.*",
-		typesRegexString,
-	)
 
-	return regexp.MustCompile(conventionalCommitBodyRegexString)
+	return Footer{}, false
 }
 
-func splitCommitMessage(message string) (body string, footers []string) {
-	segments := strings.Split(message, "\n")
-	var bodySegments []string
-	var lastBodyIndex int
-	for i, currentSegment := range segments {
-		lastBodyIndex = i
-		if currentSegment == "" {
-			break
+func ClassifyParsedCommit(commit ParsedCommit, classifier *TypeClassifier) (Classification, error) {
+	breakingFooter, hasBreakingFooter := findBreakingFooter(commit.Footers)
+
+	if commit.Type == "" {
+		if hasBreakingFooter {
+			return Classification{
+				Type:     BreakingChange,
+				Breaking: true,
+				Reason:   "footer " + breakingFooter.Token,
+			}, nil
 		}
-		bodySegments = append(bodySegments, currentSegment)
+		return Classification{
+			Type:   classifier.nonConventionalType,
+			Reason: "unparseable → " + classifier.nonConventionalType.String(),
+		}, errInvalidHeader
 	}
-	body = strings.Join(bodySegments, "\n")
-
-	var currentFooterSegments []string
-	for _, currentSegment := range segments[lastBodyIndex+1:] {
-		if currentSegment == "" {
-			footers = append(footers, strings.Join(currentFooterSegments, "\n"))
-			currentFooterSegments = []string{}
-			continue
-		}
 
-		currentFooterSegments = append(currentFooterSegments, currentSegment)
+	classification := Classification{
+		CommitType:  commit.Type,
+		Scope:       commit.Scope(),
+		Description: commit.Description,
 	}
-	footers = append(footers, strings.Join(currentFooterSegments, "\n"))
-
-	return body, footers
-}
 
-func CommitMessageToTypeWithClassifier(message string, classifier *TypeClassifier) (Type, error) {
-	body, footers := splitCommitMessage(message)
+	isBreaking := hasBreakingFooter || commit.Breaking
+	rule, hasRule := classifier.FindRule(commit.Type, commit.Scopes, isBreaking)
 
-	var breakingFooterPrefixes []string
-	for _, token := range breakingFooterTokens {
-		for _, separator := range footerTokenSeparators {
-			breakingFooterPrefixes = append(breakingFooterPrefixes, token+separator)
+	if isBreaking {
+		classification.Breaking = true
+		switch {
+		case hasRule:
+			classification.Type = rule.Bump
+			classification.Reason = fmt.Sprintf("rule %s → %s", rule, rule.Bump)
+		case hasBreakingFooter:
+			classification.Type = BreakingChange
+			classification.Reason = "footer " + breakingFooter.Token
+		default:
+			classification.Type = BreakingChange
+			classification.Reason = "breaking indicator !"
 		}
+		return classification, nil
 	}
-	for _, footer := range footers {
-		if util.IsOnePrefix(footer, breakingFooterPrefixes).IsOnePrefix {
-			return BreakingChange, nil
-		}
+
+	if !hasRule {
+		classification.Type = classifier.nonConventionalType
+		classification.Reason = "unknown type → " + classifier.nonConventionalType.String()
+		return classification, ErrUnknownType
 	}
 
-	bodyRegex := createBodyRegex(classifier)
-	parsedMessageBody := bodyRegex.FindStringSubmatch(body)
-	if parsedMessageBody == nil {
-		return Chore, errors.New("invalid message body for conventional commit message")
+	classification.Type = rule.Bump
+	if rule.Scope == "" {
+		classification.Reason = fmt.Sprintf("type %s → %s", strings.ToLower(classification.CommitType), rule.Bump)
+	} else {
+		classification.Reason = fmt.Sprintf("rule %s → %s", rule, rule.Bump)
 	}
 
-	breakingIndicatorIndex := util.MustFind(bodyRegex.SubexpNames(), "breaking")
-	breakingIndicator := parsedMessageBody[breakingIndicatorIndex]
-	if breakingIndicator == "!" {
-		return BreakingChange, nil
+	return classification, nil
+}
+
+func ClassifyCommitMessage(message string, classifier *TypeClassifier) (Classification, error) {
+	commit, parseErr := Parse(message)
+	classification, err := ClassifyParsedCommit(commit, classifier)
+	if err == errInvalidHeader {
+		return classification, parseErr
 	}
 
-	typeIndex := util.MustFind(bodyRegex.SubexpNames(), "type")
+	return classification, err
+}
 
-	return classifier.StringToType(parsedMessageBody[typeIndex])
+func CommitMessageToTypeWithClassifier(message string, classifier *TypeClassifier) (Type, error) {
+	classification, err := ClassifyCommitMessage(message, classifier)
+	return classification.Type, err
 }
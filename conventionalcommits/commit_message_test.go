@@ -45,3 +45,98 @@ func TestCommitMessageToType(t *testing.T) {
 		assert.Equal(t, test.expectedCommitType, commitType)
 	}
 }
+
+func TestClassifyCommitMessageWithRules(t *testing.T) {
+	classifier, err := conventionalcommits.NewTypeClassifierWithRules([]conventionalcommits.BumpRule{
+		{Type: "deps", Scope: "runtime", Bump: conventionalcommits.Fix},
+		{Type: "docs", Breaking: true, Bump: conventionalcommits.Chore},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		message        string
+		doExpectError  bool
+		expectedType   conventionalcommits.Type
+		expectedReason string
+	}{
+		{message: "deps(runtime): bump library", expectedType: conventionalcommits.Fix, expectedReason: "rule deps(runtime) → fix"},
+		{message: "deps(build): bump tool", doExpectError: true, expectedType: conventionalcommits.Chore, expectedReason: "unknown type → chore"},
+		{message: "docs!: rewrite guide", expectedType: conventionalcommits.Chore, expectedReason: "rule docs! → chore"},
+		{message: "docs: typo\n\nBREAKING CHANGE: none", expectedType: conventionalcommits.Chore, expectedReason: "rule docs! → chore"},
+		{message: "feat!: new api", expectedType: conventionalcommits.BreakingChange, expectedReason: "breaking indicator !"},
+		{message: "feat: new api", expectedType: conventionalcommits.Feature, expectedReason: "type feat → feature"},
+	}
+
+	for _, test := range tests {
+		classification, err := conventionalcommits.ClassifyCommitMessage(test.message, classifier)
+
+		if test.doExpectError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, test.expectedType, classification.Type, test.message)
+		assert.Equal(t, test.expectedReason, classification.Reason, test.message)
+	}
+}
+
+func TestClassifyCommitMessage(t *testing.T) {
+	classifier := conventionalcommits.NewTypeClassifier()
+
+	tests := []struct {
+		message                string
+		doExpectError          bool
+		expectedClassification conventionalcommits.Classification
+	}{
+		{
+			message: "feat(api): add endpoint",
+			expectedClassification: conventionalcommits.Classification{
+				Type: conventionalcommits.Feature, CommitType: "feat", Scope: "api", Description: "add endpoint", Reason: "type feat → feature",
+			},
+		},
+		{
+			message: "Fix: correct typo",
+			expectedClassification: conventionalcommits.Classification{
+				Type: conventionalcommits.Fix, CommitType: "Fix", Description: "correct typo", Reason: "type fix → fix",
+			},
+		},
+		{
+			message: "chore(deps)!: drop support",
+			expectedClassification: conventionalcommits.Classification{
+				Type: conventionalcommits.BreakingChange, CommitType: "chore", Scope: "deps", Breaking: true, Description: "drop support", Reason: "breaking indicator !",
+			},
+		},
+		{
+			message: "feat: new api\n\nBREAKING-CHANGE: old api removed",
+			expectedClassification: conventionalcommits.Classification{
+				Type: conventionalcommits.BreakingChange, CommitType: "feat", Breaking: true, Description: "new api", Reason: "footer BREAKING-CHANGE",
+			},
+		},
+		{
+			message: "Rewrite everything\n\nBREAKING CHANGE: all of it",
+			expectedClassification: conventionalcommits.Classification{
+				Type: conventionalcommits.BreakingChange, Breaking: true, Reason: "footer BREAKING CHANGE",
+			},
+		},
+		{
+			message:       "Some random message",
+			doExpectError: true,
+			expectedClassification: conventionalcommits.Classification{
+				Type: conventionalcommits.Chore, Reason: "unparseable → chore",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		classification, err := conventionalcommits.ClassifyCommitMessage(test.message, classifier)
+
+		if test.doExpectError {
+			assert.Error(t, err)
+		} else {
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, test.expectedClassification, classification)
+	}
+}
@@ -0,0 +1,86 @@
+package conventionalcommits
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// CommitParser parses commit messages of a commit convention into the parts
+// that are classified by bump rules. Messages that do not follow the
+// convention result in a ParseError and a ParsedCommit without type, whose
+// body and footers are still parsed.
+type CommitParser interface {
+	Parse(message string) (ParsedCommit, error)
+}
+
+// ConventionalCommitParser parses Conventional Commits, see Parse.
+type ConventionalCommitParser struct{}
+
+func NewConventionalCommitParser() *ConventionalCommitParser {
+	return &ConventionalCommitParser{}
+}
+
+func (p *ConventionalCommitParser) Parse(message string) (ParsedCommit, error) {
+	return Parse(message)
+}
+
+/*
+RegexParser parses headers with a regular expression and its named groups:
+  - type is required, headers without type do not follow the convention
+  - scope is split at commas into the scopes
+  - breaking marks a breaking change whenever it matches a non-empty text
+  - description defaults to the text after the match
+*/
+type RegexParser struct {
+	pattern *regexp.Regexp
+}
+
+func NewRegexParser(pattern string) (*RegexParser, error) {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if regex.SubexpIndex("type") < 0 {
+		return nil, errors.New("commit pattern must have a named group type")
+	}
+
+	return &RegexParser{pattern: regex}, nil
+}
+
+func (p *RegexParser) Parse(message string) (ParsedCommit, error) {
+	return parseMessage(message, p.parseHeader)
+}
+
+func (p *RegexParser) parseHeader(header string, commit *ParsedCommit) error {
+	matches := p.pattern.FindStringSubmatchIndex(header)
+	group := func(name string) string {
+		index := p.pattern.SubexpIndex(name)
+		if index < 0 || matches[2*index] < 0 {
+			return ""
+		}
+		return header[matches[2*index]:matches[2*index+1]]
+	}
+	if matches == nil || group("type") == "" {
+		return &ParseError{Line: 1, Column: 1, Message: fmt.Sprintf("header does not match the commit pattern %s", p.pattern)}
+	}
+
+	commit.Type = group("type")
+	for _, scope := range strings.Split(group("scope"), ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			commit.Scopes = append(commit.Scopes, scope)
+		}
+	}
+	if p.pattern.SubexpIndex("description") >= 0 {
+		commit.Description = strings.TrimSpace(group("description"))
+	} else {
+		commit.Description = strings.TrimSpace(header[matches[1]:])
+	}
+	if group("breaking") != "" {
+		commit.Breaking = true
+		commit.BreakingChange = commit.Description
+	}
+
+	return nil
+}
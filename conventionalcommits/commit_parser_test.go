@@ -0,0 +1,78 @@
+package conventionalcommits_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+func TestRegexParserParse(t *testing.T) {
+	jiraPattern := `^\[(?P<ticket>[A-Z]+-\d+)\] (?P<type>\w+)(?:\((?P<scope>[^)]*)\))?(?P<breaking>!)?:`
+
+	tests := []struct {
+		name           string
+		pattern        string
+		message        string
+		doExpectError  bool
+		expectedCommit conventionalcommits.ParsedCommit
+	}{
+		{
+			name:           "description after the match",
+			pattern:        jiraPattern,
+			message:        "[PROJ-123] Fix: crash on start",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "Fix", Description: "crash on start"},
+		},
+		{
+			name:    "scopes, breaking and footers",
+			pattern: jiraPattern,
+			message: "[PROJ-7] Feat(api, web)!: new login\n\nBody.\n\nReviewed-by: Jane",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type: "Feat", Scopes: []string{"api", "web"}, Breaking: true, BreakingChange: "new login", Description: "new login", Body: "Body.",
+				Footers: []conventionalcommits.Footer{{Token: "Reviewed-by", Separator: ": ", Value: "Jane"}},
+			},
+		},
+		{
+			name:           "description group",
+			pattern:        `^(?P<description>.+) \((?P<type>\w+)\)$`,
+			message:        "Add endpoint (feat)",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "feat", Description: "Add endpoint"},
+		},
+		{
+			name:          "header that does not match",
+			pattern:       jiraPattern,
+			message:       "Fix: crash on start\n\nBREAKING CHANGE: everything",
+			doExpectError: true,
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Breaking: true, BreakingChange: "everything",
+				Footers: []conventionalcommits.Footer{{Token: "BREAKING CHANGE", Separator: ": ", Value: "everything"}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := conventionalcommits.NewRegexParser(test.pattern)
+			require.NoError(t, err)
+
+			actual, err := parser.Parse(test.message)
+
+			if test.doExpectError {
+				var parseError *conventionalcommits.ParseError
+				assert.ErrorAs(t, err, &parseError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedCommit, actual)
+		})
+	}
+}
+
+func TestNewRegexParser(t *testing.T) {
+	_, err := conventionalcommits.NewRegexParser(`^(?P<kind>\w+): `)
+	assert.Error(t, err)
+
+	_, err = conventionalcommits.NewRegexParser(`^(?P<type>\w+`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,219 @@
+package conventionalcommits
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type gitmoji struct {
+	emoji      string
+	commitType string
+}
+
+// gitmojis maps the codes of the gitmojis (https://gitmoji.dev) to their emoji
+// and, for those with a Conventional Commits counterpart, its type.
+var gitmojis = map[string]gitmoji{
+	"sparkles":                  {emoji: "✨", commitType: "feat"},
+	"lipstick":                  {emoji: "💄", commitType: "feat"},
+	"boom":                      {emoji: "💥", commitType: "feat"},
+	"bug":                       {emoji: "🐛", commitType: "fix"},
+	"ambulance":                 {emoji: "🚑", commitType: "fix"},
+	"adhesive_bandage":          {emoji: "🩹", commitType: "fix"},
+	"lock":                      {emoji: "🔒", commitType: "fix"},
+	"zap":                       {emoji: "⚡", commitType: "perf"},
+	"memo":                      {emoji: "📝", commitType: "docs"},
+	"art":                       {emoji: "🎨", commitType: "style"},
+	"recycle":                   {emoji: "♻", commitType: "refactor"},
+	"fire":                      {emoji: "🔥", commitType: "refactor"},
+	"white_check_mark":          {emoji: "✅", commitType: "test"},
+	"construction_worker":       {emoji: "👷", commitType: "ci"},
+	"green_heart":               {emoji: "💚", commitType: "ci"},
+	"arrow_up":                  {emoji: "⬆", commitType: "build"},
+	"arrow_down":                {emoji: "⬇", commitType: "build"},
+	"heavy_plus_sign":           {emoji: "➕", commitType: "build"},
+	"heavy_minus_sign":          {emoji: "➖", commitType: "build"},
+	"package":                   {emoji: "📦", commitType: "build"},
+	"wrench":                    {emoji: "🔧", commitType: "chore"},
+	"bookmark":                  {emoji: "🔖", commitType: "chore"},
+	"rocket":                    {emoji: "🚀", commitType: "chore"},
+	"rewind":                    {emoji: "⏪", commitType: "revert"},
+	"tada":                      {emoji: "🎉"},
+	"closed_lock_with_key":      {emoji: "🔐"},
+	"rotating_light":            {emoji: "🚨"},
+	"construction":              {emoji: "🚧"},
+	"pushpin":                   {emoji: "📌"},
+	"chart_with_upwards_trend":  {emoji: "📈"},
+	"hammer":                    {emoji: "🔨"},
+	"globe_with_meridians":      {emoji: "🌐"},
+	"pencil2":                   {emoji: "✏"},
+	"poop":                      {emoji: "💩"},
+	"twisted_rightwards_arrows": {emoji: "🔀"},
+	"alien":                     {emoji: "👽"},
+	"truck":                     {emoji: "🚚"},
+	"page_facing_up":            {emoji: "📄"},
+	"bento":                     {emoji: "🍱"},
+	"wheelchair":                {emoji: "♿"},
+	"bulb":                      {emoji: "💡"},
+	"beers":                     {emoji: "🍻"},
+	"speech_balloon":            {emoji: "💬"},
+	"card_file_box":             {emoji: "🗃"},
+	"loud_sound":                {emoji: "🔊"},
+	"mute":                      {emoji: "🔇"},
+	"busts_in_silhouette":       {emoji: "👥"},
+	"children_crossing":         {emoji: "🚸"},
+	"building_construction":     {emoji: "🏗"},
+	"iphone":                    {emoji: "📱"},
+	"clown_face":                {emoji: "🤡"},
+	"egg":                       {emoji: "🥚"},
+	"see_no_evil":               {emoji: "🙈"},
+	"camera_flash":              {emoji: "📸"},
+	"alembic":                   {emoji: "⚗"},
+	"mag":                       {emoji: "🔍"},
+	"label":                     {emoji: "🏷"},
+	"seedling":                  {emoji: "🌱"},
+	"triangular_flag_on_post":   {emoji: "🚩"},
+	"goal_net":                  {emoji: "🥅"},
+	"dizzy":                     {emoji: "💫"},
+	"wastebasket":               {emoji: "🗑"},
+	"passport_control":          {emoji: "🛂"},
+	"monocle_face":              {emoji: "🧐"},
+	"coffin":                    {emoji: "⚰"},
+	"test_tube":                 {emoji: "🧪"},
+	"necktie":                   {emoji: "👔"},
+	"stethoscope":               {emoji: "🩺"},
+	"bricks":                    {emoji: "🧱"},
+	"technologist":              {emoji: "🧑\u200d💻"},
+	"money_with_wings":          {emoji: "💸"},
+	"thread":                    {emoji: "🧵"},
+	"safety_vest":               {emoji: "🦺"},
+	"airplane":                  {emoji: "✈"},
+}
+
+var (
+	gitmojiCodeRegex    = regexp.MustCompile(`^:([a-z0-9_+\-]+):`)
+	gitmojiScopeRegex   = regexp.MustCompile(`^\(([^()]*)\)`)
+	gitmojiCodesByEmoji = func() map[string]string {
+		codes := make(map[string]string, len(gitmojis))
+		for code, gitmoji := range gitmojis {
+			codes[gitmoji.emoji] = code
+		}
+		return codes
+	}()
+)
+
+// variationSelector follows some emojis, e.g. ⚡️, and is ignored. Several
+// emojis joined by zeroWidthJoiner form a single one, e.g. 🧑‍💻.
+const (
+	variationSelector = '\ufe0f'
+	zeroWidthJoiner   = '\u200d'
+)
+
+// isSkinToneModifier reports whether the rune changes the skin tone of the
+// emoji before it, e.g. 👷🏽, which does not change its meaning.
+func isSkinToneModifier(r rune) bool {
+	return r >= 0x1f3fb && r <= 0x1f3ff
+}
+
+// normalizeEmoji removes variation selectors and skin tone modifiers.
+func normalizeEmoji(emoji string) string {
+	return strings.Map(func(r rune) rune {
+		if r == variationSelector || isSkinToneModifier(r) {
+			return -1
+		}
+		return r
+	}, emoji)
+}
+
+// leadingEmoji returns the emoji at the start of the header, including the
+// variation selectors, skin tone modifiers and joined emojis that follow it.
+func leadingEmoji(header string) string {
+	first, size := utf8.DecodeRuneInString(header)
+	if !unicode.Is(unicode.So, first) {
+		return ""
+	}
+
+	end := size
+	for end < len(header) {
+		r, size := utf8.DecodeRuneInString(header[end:])
+		switch {
+		case r == variationSelector || isSkinToneModifier(r):
+			end += size
+		case r == zeroWidthJoiner:
+			next, nextSize := utf8.DecodeRuneInString(header[end+size:])
+			if !unicode.Is(unicode.So, next) {
+				return header[:end]
+			}
+			end += size + nextSize
+		default:
+			return header[:end]
+		}
+	}
+	return header[:end]
+}
+
+/*
+GitmojiParser parses gitmoji commits (https://gitmoji.dev):
+
+	<:code: or emoji> [(<scope>[,<scope>...])][!][:] <description>
+
+Codes and emojis are equivalent, e.g. :truck: and 🚚 both have the type truck.
+Gitmojis with a Conventional Commits counterpart get its type, e.g. :sparkles:
+is feat and :bug: is fix, :boom: is a breaking feat. Other gitmojis keep their
+code as type, e.g. truck, so that bump rules can refer to them, and emojis that
+are not gitmojis keep the emoji as type.
+*/
+type GitmojiParser struct{}
+
+func NewGitmojiParser() *GitmojiParser {
+	return &GitmojiParser{}
+}
+
+func (p *GitmojiParser) Parse(message string) (ParsedCommit, error) {
+	return parseMessage(message, parseGitmojiHeader)
+}
+
+func parseGitmojiHeader(header string, commit *ParsedCommit) error {
+	var code, rest string
+	if matches := gitmojiCodeRegex.FindStringSubmatch(header); matches != nil {
+		code, rest = matches[1], header[len(matches[0]):]
+	} else if emoji := leadingEmoji(header); emoji != "" {
+		code, rest = normalizeEmoji(emoji), header[len(emoji):]
+		if emojiCode, ok := gitmojiCodesByEmoji[code]; ok {
+			code = emojiCode
+		}
+	} else {
+		return &ParseError{Line: 1, Column: 1, Message: "expected a gitmoji"}
+	}
+
+	rest = strings.TrimLeft(rest, " ")
+	var scopes []string
+	if matches := gitmojiScopeRegex.FindStringSubmatch(rest); matches != nil {
+		for _, scope := range strings.Split(matches[1], ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+		rest = rest[len(matches[0]):]
+	}
+	isBreaking := code == "boom"
+	if strings.HasPrefix(rest, "!") {
+		isBreaking = true
+		rest = rest[1:]
+	}
+	rest = strings.TrimPrefix(rest, ":")
+
+	commit.Type = code
+	if gitmoji, ok := gitmojis[code]; ok && gitmoji.commitType != "" {
+		commit.Type = gitmoji.commitType
+	}
+	commit.Scopes = scopes
+	commit.Breaking = isBreaking
+	commit.Description = strings.TrimSpace(rest)
+	if isBreaking {
+		commit.BreakingChange = commit.Description
+	}
+
+	return nil
+}
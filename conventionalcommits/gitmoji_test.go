@@ -0,0 +1,93 @@
+package conventionalcommits_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+func TestGitmojiParserParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        string
+		doExpectError  bool
+		expectedCommit conventionalcommits.ParsedCommit
+	}{
+		{
+			name:           "code",
+			message:        ":sparkles: add endpoint",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "feat", Description: "add endpoint"},
+		},
+		{
+			name:           "emoji with scope",
+			message:        "🐛 (api,cli): fix crash",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "fix", Scopes: []string{"api", "cli"}, Description: "fix crash"},
+		},
+		{
+			name:           "emoji with variation selector and without space",
+			message:        "⚡️make it fast",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "perf", Description: "make it fast"},
+		},
+		{
+			name:    "boom is breaking",
+			message: "💥 drop flag\n\nBody.",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type: "feat", Breaking: true, BreakingChange: "drop flag", Description: "drop flag", Body: "Body.",
+			},
+		},
+		{
+			name:    "breaking indicator and footers",
+			message: ":recycle:(core)!: rename options\n\nRefs: #12",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type: "refactor", Scopes: []string{"core"}, Breaking: true, BreakingChange: "rename options", Description: "rename options",
+				Footers: []conventionalcommits.Footer{{Token: "Refs", Separator: ": ", Value: "#12"}},
+			},
+		},
+		{
+			name:           "unknown code keeps the code as type",
+			message:        ":truck: move files",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "truck", Description: "move files"},
+		},
+		{
+			name:           "emoji without counterpart keeps the code as type",
+			message:        "🚚 move files",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "truck", Description: "move files"},
+		},
+		{
+			name:           "joined emoji",
+			message:        "🧑\u200d💻 (cli): improve messages",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "technologist", Scopes: []string{"cli"}, Description: "improve messages"},
+		},
+		{
+			name:           "emoji with skin tone modifier",
+			message:        "👷🏽 add workflow",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "ci", Description: "add workflow"},
+		},
+		{
+			name:           "emoji that is not a gitmoji keeps the emoji as type",
+			message:        "🦄 add magic",
+			expectedCommit: conventionalcommits.ParsedCommit{Type: "🦄", Description: "add magic"},
+		},
+		{
+			name:           "missing gitmoji",
+			message:        "feat: add endpoint",
+			doExpectError:  true,
+			expectedCommit: conventionalcommits.ParsedCommit{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := conventionalcommits.NewGitmojiParser().Parse(test.message)
+
+			if test.doExpectError {
+				var parseError *conventionalcommits.ParseError
+				assert.ErrorAs(t, err, &parseError)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, test.expectedCommit, actual)
+		})
+	}
+}
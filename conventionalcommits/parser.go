@@ -0,0 +1,205 @@
+package conventionalcommits
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	breakingFooterTokens = []string{"BREAKING CHANGE", "BREAKING-CHANGE"}
+	footerRegex          = regexp.MustCompile(`^(BREAKING CHANGE|[A-Za-z][A-Za-z\d\-]*)(: | #)(.*)$`)
+)
+
+type Footer struct {
+	Token string
+	// Separator is either ": " or " #".
+	Separator string
+	Value     string
+}
+
+type ParsedCommit struct {
+	// Type is empty if the header could not be parsed.
+	Type   string
+	Scopes []string
+	// Breaking is set by the ! indicator or a BREAKING CHANGE footer.
+	Breaking bool
+	// BreakingChange describes the breaking change, taken from the BREAKING
+	// CHANGE footer or, for the ! indicator only, from the description.
+	BreakingChange string
+	Description    string
+	Body           string
+	Footers        []Footer
+}
+
+// ParseError reports where a commit message violates the specification.
+// Line and Column are 1-based.
+type ParseError struct {
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+}
+
+func (c ParsedCommit) Scope() string {
+	return strings.Join(c.Scopes, ",")
+}
+
+func (c ParsedCommit) Footer(token string) (Footer, bool) {
+	for _, footer := range c.Footers {
+		if strings.EqualFold(footer.Token, token) {
+			return footer, true
+		}
+	}
+
+	return Footer{}, false
+}
+
+/*
+Parse parses a commit message according to the Conventional Commits
+specification (https://www.conventionalcommits.org/en/v1.0.0/#specification):
+
+	<type>[(<scope>[,<scope>...])][!]: <description>
+
+	[body]
+
+	[footers]
+
+The body and the footers are parsed even if the header is invalid, so that a
+BREAKING CHANGE footer is never missed. Footers start at the first paragraph
+whose first line is a footer; lines that are not footers continue the value of
+the previous footer. To accept existing histories, the space after the colon
+and the description may be omitted, and lines directly following the header
+are treated as body.
+*/
+func Parse(message string) (ParsedCommit, error) {
+	return parseMessage(message, parseHeader)
+}
+
+// parseMessage parses the body and the footers of a message, which are the
+// same for every convention, and the header with parseHeader.
+func parseMessage(message string, parseHeader func(header string, commit *ParsedCommit) error) (ParsedCommit, error) {
+	message = strings.ReplaceAll(message, "\r\n", "\n")
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+
+	var commit ParsedCommit
+	headerErr := parseHeader(lines[0], &commit)
+
+	bodyLines, footerLines := splitBodyAndFooters(lines[1:])
+	commit.Body = strings.Trim(strings.Join(bodyLines, "\n"), "\n")
+	commit.Footers = parseFooters(footerLines)
+
+	for _, footer := range commit.Footers {
+		if isBreakingFooterToken(footer.Token) {
+			commit.Breaking = true
+			commit.BreakingChange = footer.Value
+			break
+		}
+	}
+	if commit.Breaking && commit.BreakingChange == "" && commit.Type != "" {
+		commit.BreakingChange = commit.Description
+	}
+
+	if headerErr != nil {
+		return commit, headerErr
+	}
+
+	return commit, nil
+}
+
+func parseHeader(header string, commit *ParsedCommit) error {
+	position := 0
+	for position < len(header) && isTypeCharacter(header[position]) {
+		position++
+	}
+	if position == 0 {
+		return &ParseError{Line: 1, Column: 1, Message: "expected a type"}
+	}
+	commitType := header[:position]
+
+	var scopes []string
+	if position < len(header) && header[position] == '(' {
+		closingIndex := strings.IndexAny(header[position+1:], "()")
+		if closingIndex < 0 || header[position+1+closingIndex] == '(' {
+			return &ParseError{Line: 1, Column: position + 1, Message: "unterminated scope"}
+		}
+		for i, scope := range strings.Split(header[position+1:position+1+closingIndex], ",") {
+			scope = strings.TrimSpace(scope)
+			if scope == "" {
+				return &ParseError{Line: 1, Column: position + 2, Message: fmt.Sprintf("empty scope at index %d", i)}
+			}
+			scopes = append(scopes, scope)
+		}
+		position += closingIndex + 2
+	}
+
+	isBreaking := false
+	if position < len(header) && header[position] == '!' {
+		isBreaking = true
+		position++
+	}
+
+	if position >= len(header) || header[position] != ':' {
+		return &ParseError{Line: 1, Column: position + 1, Message: "expected ':' after type"}
+	}
+	position++
+
+	commit.Type = commitType
+	commit.Scopes = scopes
+	commit.Breaking = isBreaking
+	commit.Description = strings.TrimSpace(header[position:])
+	if isBreaking {
+		commit.BreakingChange = commit.Description
+	}
+
+	return nil
+}
+
+func isTypeCharacter(character byte) bool {
+	return character >= 'a' && character <= 'z' ||
+		character >= 'A' && character <= 'Z' ||
+		character >= '0' && character <= '9' ||
+		character == '-' || character == '_'
+}
+
+func splitBodyAndFooters(lines []string) (bodyLines []string, footerLines []string) {
+	for i, line := range lines {
+		isParagraphStart := i > 0 && lines[i-1] == ""
+		if isParagraphStart && footerRegex.MatchString(line) {
+			return lines[:i], lines[i:]
+		}
+	}
+
+	return lines, nil
+}
+
+func parseFooters(lines []string) []Footer {
+	var footers []Footer
+	for _, line := range lines {
+		if matches := footerRegex.FindStringSubmatch(line); matches != nil {
+			footers = append(footers, Footer{Token: matches[1], Separator: matches[2], Value: matches[3]})
+			continue
+		}
+		last := &footers[len(footers)-1]
+		last.Value += "\n" + line
+	}
+
+	for i := range footers {
+		footers[i].Value = strings.TrimSpace(footers[i].Value)
+	}
+
+	return footers
+}
+
+func isBreakingFooterToken(token string) bool {
+	for _, breakingFooterToken := range breakingFooterTokens {
+		if token == breakingFooterToken {
+			return true
+		}
+	}
+
+	return false
+}
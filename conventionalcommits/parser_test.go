@@ -0,0 +1,141 @@
+package conventionalcommits_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name           string
+		message        string
+		expectedCommit conventionalcommits.ParsedCommit
+	}{
+		{
+			name:    "header only",
+			message: "feat: add endpoint",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type: "feat", Description: "add endpoint",
+			},
+		},
+		{
+			name:    "multiple scopes and breaking indicator",
+			message: "fix(api, cli)!: drop flag",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type: "fix", Scopes: []string{"api", "cli"}, Breaking: true, BreakingChange: "drop flag", Description: "drop flag",
+			},
+		},
+		{
+			name:    "body with multiple paragraphs and footers",
+			message: "feat(parser): support footers\n\nFirst paragraph\nstill first.\n\nSecond paragraph.\n\nReviewed-by: Jane Doe\nRefs #123\nBREAKING CHANGE: footers are now\n  parsed strictly\n",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type:           "feat",
+				Scopes:         []string{"parser"},
+				Breaking:       true,
+				BreakingChange: "footers are now\n  parsed strictly",
+				Description:    "support footers",
+				Body:           "First paragraph\nstill first.\n\nSecond paragraph.",
+				Footers: []conventionalcommits.Footer{
+					{Token: "Reviewed-by", Separator: ": ", Value: "Jane Doe"},
+					{Token: "Refs", Separator: " #", Value: "123"},
+					{Token: "BREAKING CHANGE", Separator: ": ", Value: "footers are now\n  parsed strictly"},
+				},
+			},
+		},
+		{
+			name:    "footer value spanning paragraphs",
+			message: "fix: bug\n\nBREAKING-CHANGE: first\n\nsecond\nAcked-by: John",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type:           "fix",
+				Breaking:       true,
+				BreakingChange: "first\n\nsecond",
+				Description:    "bug",
+				Footers: []conventionalcommits.Footer{
+					{Token: "BREAKING-CHANGE", Separator: ": ", Value: "first\n\nsecond"},
+					{Token: "Acked-by", Separator: ": ", Value: "John"},
+				},
+			},
+		},
+		{
+			name:    "lines directly after the header are body",
+			message: "chore:Some Description\nBREAKING-CHANGE: not a footer",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type: "chore", Description: "Some Description", Body: "BREAKING-CHANGE: not a footer",
+			},
+		},
+		{
+			name:    "lower case breaking change token is not breaking",
+			message: "fix: bug\n\nbreaking-change: no",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type:        "fix",
+				Description: "bug",
+				Footers:     []conventionalcommits.Footer{{Token: "breaking-change", Separator: ": ", Value: "no"}},
+			},
+		},
+		{
+			name:    "windows line endings",
+			message: "fix: bug\r\n\r\nbody\r\n",
+			expectedCommit: conventionalcommits.ParsedCommit{
+				Type: "fix", Description: "bug", Body: "body",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			commit, err := conventionalcommits.Parse(test.message)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCommit, commit)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		message       string
+		expectedError string
+	}{
+		{message: "", expectedError: "1:1: expected a type"},
+		{message: ": description", expectedError: "1:1: expected a type"},
+		{message: "Some random message", expectedError: "1:5: expected ':' after type"},
+		{message: "feat(api: description", expectedError: "1:5: unterminated scope"},
+		{message: "feat(a(b)): description", expectedError: "1:5: unterminated scope"},
+		{message: "feat(): description", expectedError: "1:6: empty scope at index 0"},
+		{message: "feat(api,): description", expectedError: "1:6: empty scope at index 1"},
+		{message: "feat(api)! description", expectedError: "1:11: expected ':' after type"},
+		{message: "feat", expectedError: "1:5: expected ':' after type"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			_, err := conventionalcommits.Parse(test.message)
+
+			var parseError *conventionalcommits.ParseError
+			require.ErrorAs(t, err, &parseError)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+
+	t.Run("parses footers of an invalid header", func(t *testing.T) {
+		commit, err := conventionalcommits.Parse("Rewrite everything\n\nBREAKING CHANGE: all of it")
+		assert.Error(t, err)
+		assert.Equal(t, "", commit.Type)
+		assert.True(t, commit.Breaking)
+		assert.Equal(t, "all of it", commit.BreakingChange)
+	})
+}
+
+func TestParsedCommitFooter(t *testing.T) {
+	commit, err := conventionalcommits.Parse("fix: bug\n\nRelease-As: 2.0.0")
+	require.NoError(t, err)
+
+	footer, ok := commit.Footer("release-as")
+	assert.True(t, ok)
+	assert.Equal(t, "2.0.0", footer.Value)
+
+	_, ok = commit.Footer("Refs")
+	assert.False(t, ok)
+}
@@ -0,0 +1,95 @@
+package conventionalcommits
+
+import (
+	"regexp"
+	"strings"
+)
+
+/*
+ScopeFilter decides whether a commit counts towards the next version based on
+its scopes:
+  - a scope is accepted if it matches one of the include patterns (or there
+    are none) and none of the exclude patterns
+  - a commit with scopes is included if at least one of its scopes is accepted
+  - a commit without scopes is included if IncludeUnscoped is set
+
+Patterns are globs where * matches any sequence of characters and ? a single
+character, or regular expressions when enclosed in slashes, e.g. /^api-.+$/.
+*/
+type ScopeFilter struct {
+	Include         []*regexp.Regexp
+	Exclude         []*regexp.Regexp
+	IncludeUnscoped bool
+}
+
+func NewScopeFilter(include, exclude []string, includeUnscoped bool) (*ScopeFilter, error) {
+	filter := &ScopeFilter{IncludeUnscoped: includeUnscoped}
+
+	var err error
+	if filter.Include, err = compileScopePatterns(include); err != nil {
+		return nil, err
+	}
+	if filter.Exclude, err = compileScopePatterns(exclude); err != nil {
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+func (f *ScopeFilter) Matches(scopes []string) bool {
+	if len(scopes) == 0 {
+		return f.IncludeUnscoped
+	}
+
+	for _, scope := range scopes {
+		if (len(f.Include) == 0 || matchesAnyPattern(scope, f.Include)) && !matchesAnyPattern(scope, f.Exclude) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func compileScopePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	regexes := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		regex, err := compileScopePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+		regexes = append(regexes, regex)
+	}
+
+	return regexes, nil
+}
+
+func compileScopePattern(pattern string) (*regexp.Regexp, error) {
+	if len(pattern) > 1 && strings.HasPrefix(pattern, "/") && strings.HasSuffix(pattern, "/") {
+		return regexp.Compile(pattern[1 : len(pattern)-1])
+	}
+
+	var regexStr strings.Builder
+	regexStr.WriteString("^")
+	for _, char := range pattern {
+		switch char {
+		case '*':
+			regexStr.WriteString(".*")
+		case '?':
+			regexStr.WriteString(".")
+		default:
+			regexStr.WriteString(regexp.QuoteMeta(string(char)))
+		}
+	}
+	regexStr.WriteString("$")
+
+	return regexp.Compile(regexStr.String())
+}
+
+func matchesAnyPattern(scope string, regexes []*regexp.Regexp) bool {
+	for _, regex := range regexes {
+		if regex.MatchString(scope) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package conventionalcommits_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+func TestScopeFilter(t *testing.T) {
+	tests := []struct {
+		name            string
+		include         []string
+		exclude         []string
+		includeUnscoped bool
+		scopes          []string
+		expectedMatch   bool
+	}{
+		{name: "included scope", include: []string{"api"}, scopes: []string{"api"}, expectedMatch: true},
+		{name: "not included scope", include: []string{"api"}, scopes: []string{"web"}, expectedMatch: false},
+		{name: "glob", include: []string{"api-*"}, scopes: []string{"api-v2"}, expectedMatch: true},
+		{name: "glob matches the whole scope", include: []string{"api"}, scopes: []string{"api-v2"}, expectedMatch: false},
+		{name: "single character glob", include: []string{"v?"}, scopes: []string{"v2"}, expectedMatch: true},
+		{name: "regex", include: []string{"/^(api|web)$/"}, scopes: []string{"web"}, expectedMatch: true},
+		{name: "excluded scope", exclude: []string{"docs"}, scopes: []string{"docs"}, expectedMatch: false},
+		{name: "exclusion wins over inclusion", include: []string{"*"}, exclude: []string{"web"}, scopes: []string{"web"}, expectedMatch: false},
+		{name: "any accepted scope", include: []string{"api"}, scopes: []string{"web", "api"}, expectedMatch: true},
+		{name: "unscoped excluded", include: []string{"api"}, scopes: nil, expectedMatch: false},
+		{name: "unscoped included", include: []string{"api"}, includeUnscoped: true, scopes: nil, expectedMatch: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filter, err := conventionalcommits.NewScopeFilter(test.include, test.exclude, test.includeUnscoped)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedMatch, filter.Matches(test.scopes))
+		})
+	}
+}
+
+func TestNewScopeFilterErrors(t *testing.T) {
+	_, err := conventionalcommits.NewScopeFilter([]string{"/(/"}, nil, true)
+	assert.Error(t, err)
+
+	_, err = conventionalcommits.NewScopeFilter(nil, []string{"/[/"}, true)
+	assert.Error(t, err)
+}
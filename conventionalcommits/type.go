@@ -2,6 +2,8 @@ package conventionalcommits
 
 import (
 	"errors"
+	"fmt"
+	"path"
 	"slices"
 	"strings"
 )
@@ -21,66 +23,210 @@ var (
 	defaultFeatureTypes = []string{"feat"}
 )
 
+var ErrUnknownType = errors.New("invalid string for conventional commit type")
+
+/*
+BumpRule assigns a change type, and therefore a version bump, to commits:
+  - Type is the conventional commit type, matched case-insensitively
+  - Scope is an optional path.Match pattern, a rule with a scope only applies to
+    commits with at least one matching scope
+  - Breaking rules only apply to breaking changes, all others only to regular
+    commits; breaking changes without a matching rule always bump the major version
+*/
+type BumpRule struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	Bump     Type
+}
+
 type TypeClassifier struct {
-	choreTypes   []string
-	fixTypes     []string
-	featureTypes []string
+	rules               []BumpRule
+	nonConventionalType Type
 }
 
 func NewTypeClassifier() *TypeClassifier {
-	return &TypeClassifier{
-		choreTypes:   append([]string{}, defaultChoreTypes...),
-		fixTypes:     append([]string{}, defaultFixTypes...),
-		featureTypes: append([]string{}, defaultFeatureTypes...),
-	}
+	return NewTypeClassifierWithCustomPrefixes(nil, nil, nil)
 }
 
+// NewTypeClassifierWithCustomPrefixes creates a classifier with one rule per
+// prefix. Empty lists keep their defaults, and a prefix listed more than once
+// gets the highest of its bumps.
 func NewTypeClassifierWithCustomPrefixes(customChoreTypes, customFixTypes, customFeatureTypes []string) *TypeClassifier {
+	prefixes := []struct {
+		types        []string
+		defaultTypes []string
+		bump         Type
+	}{
+		{types: customFeatureTypes, defaultTypes: defaultFeatureTypes, bump: Feature},
+		{types: customFixTypes, defaultTypes: defaultFixTypes, bump: Fix},
+		{types: customChoreTypes, defaultTypes: defaultChoreTypes, bump: Chore},
+	}
+
 	tc := &TypeClassifier{}
-	
-	if len(customChoreTypes) > 0 {
-		tc.choreTypes = customChoreTypes
-	} else {
-		tc.choreTypes = append([]string{}, defaultChoreTypes...)
+	added := make(map[string]bool)
+	for _, prefix := range prefixes {
+		types := prefix.types
+		if len(types) == 0 {
+			types = prefix.defaultTypes
+		}
+		for _, commitType := range types {
+			commitType = strings.ToLower(commitType)
+			if added[commitType] {
+				continue
+			}
+			added[commitType] = true
+			tc.rules = append(tc.rules, BumpRule{Type: commitType, Bump: prefix.bump})
+		}
 	}
-	
-	if len(customFixTypes) > 0 {
-		tc.fixTypes = customFixTypes
-	} else {
-		tc.fixTypes = append([]string{}, defaultFixTypes...)
+
+	return tc
+}
+
+// NewTypeClassifierWithRules creates a classifier from a bump rule table on top
+// of the default rules, which are overridden by rules for the same type. Rules
+// with the same type, scope and breaking flag are rejected, whether they agree
+// on the bump or not. Scope patterns that overlap without being identical are
+// accepted, and the first matching rule wins, see FindRule.
+func NewTypeClassifierWithRules(rules []BumpRule) (*TypeClassifier, error) {
+	seen := make(map[BumpRule]BumpRule)
+	for _, rule := range rules {
+		if rule.Type == "" {
+			return nil, errors.New("bump rule without type")
+		}
+		if _, err := path.Match(rule.Scope, ""); err != nil {
+			return nil, fmt.Errorf("bump rule %s: invalid scope pattern: %w", rule, err)
+		}
+		if _, ok := bumpLevels[rule.Bump]; !ok {
+			return nil, fmt.Errorf("bump rule %s: invalid bump %s", rule, rule.Bump)
+		}
+
+		key := BumpRule{Type: strings.ToLower(rule.Type), Scope: rule.Scope, Breaking: rule.Breaking}
+		if previous, ok := seen[key]; ok {
+			if previous.Bump == rule.Bump {
+				return nil, fmt.Errorf("duplicate bump rule %s", key)
+			}
+			return nil, fmt.Errorf("conflicting bump rules for %s: %s and %s", key, bumpLevels[previous.Bump], bumpLevels[rule.Bump])
+		}
+		seen[key] = rule
 	}
-	
-	if len(customFeatureTypes) > 0 {
-		tc.featureTypes = customFeatureTypes
-	} else {
-		tc.featureTypes = append([]string{}, defaultFeatureTypes...)
+
+	tc := &TypeClassifier{rules: append([]BumpRule{}, rules...)}
+	for _, defaultRule := range NewTypeClassifier().rules {
+		if _, ok := seen[BumpRule{Type: defaultRule.Type}]; !ok {
+			tc.rules = append(tc.rules, defaultRule)
+		}
 	}
-	
-	return tc
+
+	return tc, nil
+}
+
+// SetNonConventionalType sets the type of commits that are not conventional
+// commits, i.e. whose header cannot be parsed or whose type is unknown. It
+// defaults to Chore.
+func (tc *TypeClassifier) SetNonConventionalType(t Type) {
+	tc.nonConventionalType = t
 }
 
+// GetAllTypes returns the types of all rules, in lower case and without
+// duplicates.
 func (tc *TypeClassifier) GetAllTypes() []string {
 	var allTypes []string
-	for _, types := range [][]string{tc.choreTypes, tc.fixTypes, tc.featureTypes} {
-		allTypes = append(allTypes, types...)
+	for _, rule := range tc.rules {
+		commitType := strings.ToLower(rule.Type)
+		if !slices.Contains(allTypes, commitType) {
+			allTypes = append(allTypes, commitType)
+		}
 	}
 	return allTypes
 }
 
 func (tc *TypeClassifier) StringToType(s string) (Type, error) {
-	lowerS := strings.ToLower(s)
-	
-	if slices.Contains(tc.featureTypes, lowerS) {
-		return Feature, nil
+	rule, ok := tc.FindRule(s, nil, false)
+	if !ok {
+		return Chore, ErrUnknownType
+	}
+
+	return rule.Bump, nil
+}
+
+// FindRule returns the rule that applies to a commit. Rules with a scope take
+// precedence over rules without, otherwise the first matching rule wins, e.g.
+// for overlapping scope patterns or a commit with several scopes.
+func (tc *TypeClassifier) FindRule(commitType string, scopes []string, breaking bool) (BumpRule, bool) {
+	var unscopedRule *BumpRule
+	for i, rule := range tc.rules {
+		if rule.Breaking != breaking || !strings.EqualFold(rule.Type, commitType) {
+			continue
+		}
+		if rule.Scope == "" {
+			if unscopedRule == nil {
+				unscopedRule = &tc.rules[i]
+			}
+			continue
+		}
+		if matchesAnyScope(rule.Scope, scopes) {
+			return rule, true
+		}
+	}
+
+	if unscopedRule == nil {
+		return BumpRule{}, false
 	}
+	return *unscopedRule, true
+}
+
+func matchesAnyScope(pattern string, scopes []string) bool {
+	for _, scope := range scopes {
+		if matches, _ := path.Match(pattern, scope); matches {
+			return true
+		}
+	}
+	return false
+}
 
-	if slices.Contains(tc.fixTypes, lowerS) {
-		return Fix, nil
+func (r BumpRule) String() string {
+	var builder strings.Builder
+	builder.WriteString(strings.ToLower(r.Type))
+	if r.Scope != "" {
+		builder.WriteString("(" + r.Scope + ")")
 	}
+	if r.Breaking {
+		builder.WriteString("!")
+	}
+	return builder.String()
+}
+
+var bumpLevels = map[Type]string{
+	Chore:          "none",
+	Fix:            "patch",
+	Feature:        "minor",
+	BreakingChange: "major",
+}
+
+// ParseBump converts a bump level (none, patch, minor or major) into the type
+// of change that results in it.
+func ParseBump(bump string) (Type, error) {
+	for commitType, level := range bumpLevels {
+		if strings.EqualFold(bump, level) {
+			return commitType, nil
+		}
+	}
+
+	return Chore, errors.New("bump must be none, patch, minor or major")
+}
 
-	if slices.Contains(tc.choreTypes, lowerS) {
-		return Chore, nil
+func (t Type) String() string {
+	switch t {
+	case Chore:
+		return "chore"
+	case Fix:
+		return "fix"
+	case Feature:
+		return "feature"
+	case BreakingChange:
+		return "breaking change"
 	}
 
-	return Chore, errors.New("invalid string for conventional commit type")
+	return fmt.Sprintf("Type(%d)", int(t))
 }
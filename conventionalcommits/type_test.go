@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tvcsantos/get-next-version/conventionalcommits"
 )
 
@@ -138,3 +139,122 @@ func TestNewTypeClassifier(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, conventionalcommits.Chore, commitType)
 }
+
+func TestNewTypeClassifierWithRules(t *testing.T) {
+	classifier, err := conventionalcommits.NewTypeClassifierWithRules([]conventionalcommits.BumpRule{
+		{Type: "perf", Bump: conventionalcommits.Fix},
+		{Type: "deps", Bump: conventionalcommits.Chore},
+		{Type: "deps", Scope: "runtime", Bump: conventionalcommits.Fix},
+		{Type: "security", Bump: conventionalcommits.Feature},
+		{Type: "docs", Breaking: true, Bump: conventionalcommits.Chore},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		commitType    string
+		scopes        []string
+		breaking      bool
+		expectedRule  conventionalcommits.BumpRule
+		doExpectMatch bool
+	}{
+		{commitType: "perf", expectedRule: conventionalcommits.BumpRule{Type: "perf", Bump: conventionalcommits.Fix}, doExpectMatch: true},
+		{commitType: "deps", expectedRule: conventionalcommits.BumpRule{Type: "deps", Bump: conventionalcommits.Chore}, doExpectMatch: true},
+		{commitType: "deps", scopes: []string{"build", "runtime"}, expectedRule: conventionalcommits.BumpRule{Type: "deps", Scope: "runtime", Bump: conventionalcommits.Fix}, doExpectMatch: true},
+		{commitType: "Security", expectedRule: conventionalcommits.BumpRule{Type: "security", Bump: conventionalcommits.Feature}, doExpectMatch: true},
+		{commitType: "docs", breaking: true, expectedRule: conventionalcommits.BumpRule{Type: "docs", Breaking: true, Bump: conventionalcommits.Chore}, doExpectMatch: true},
+		{commitType: "feat", expectedRule: conventionalcommits.BumpRule{Type: "feat", Bump: conventionalcommits.Feature}, doExpectMatch: true},
+		{commitType: "feat", breaking: true, doExpectMatch: false},
+		{commitType: "unknown", doExpectMatch: false},
+	}
+
+	for _, test := range tests {
+		rule, ok := classifier.FindRule(test.commitType, test.scopes, test.breaking)
+
+		assert.Equal(t, test.doExpectMatch, ok, test.commitType)
+		assert.Equal(t, test.expectedRule, rule, test.commitType)
+	}
+
+	assert.Equal(t, []string{"perf", "deps", "security", "docs", "feat", "fix", "build", "chore", "ci", "style", "refactor", "test"}, classifier.GetAllTypes())
+}
+
+func TestNewTypeClassifierWithRulesErrors(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         []conventionalcommits.BumpRule
+		expectedError string
+	}{
+		{
+			name:          "missing type",
+			rules:         []conventionalcommits.BumpRule{{Bump: conventionalcommits.Fix}},
+			expectedError: "bump rule without type",
+		},
+		{
+			name:          "invalid scope pattern",
+			rules:         []conventionalcommits.BumpRule{{Type: "deps", Scope: "[", Bump: conventionalcommits.Fix}},
+			expectedError: "bump rule deps([): invalid scope pattern: syntax error in pattern",
+		},
+		{
+			name: "duplicate rules",
+			rules: []conventionalcommits.BumpRule{
+				{Type: "deps", Scope: "runtime", Bump: conventionalcommits.Fix},
+				{Type: "Deps", Scope: "runtime", Bump: conventionalcommits.Fix},
+			},
+			expectedError: "duplicate bump rule deps(runtime)",
+		},
+		{
+			name: "conflicting rules",
+			rules: []conventionalcommits.BumpRule{
+				{Type: "security", Bump: conventionalcommits.Feature},
+				{Type: "security", Bump: conventionalcommits.Fix},
+			},
+			expectedError: "conflicting bump rules for security: minor and patch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := conventionalcommits.NewTypeClassifierWithRules(test.rules)
+			assert.EqualError(t, err, test.expectedError)
+		})
+	}
+}
+
+func TestNewTypeClassifierWithRulesFirstMatchingScopeWins(t *testing.T) {
+	classifier, err := conventionalcommits.NewTypeClassifierWithRules([]conventionalcommits.BumpRule{
+		{Type: "deps", Scope: "deps/runtime", Bump: conventionalcommits.Fix},
+		{Type: "deps", Scope: "deps/run*", Bump: conventionalcommits.Feature},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		scopes       []string
+		expectedBump conventionalcommits.Type
+	}{
+		{scopes: []string{"deps/runtime"}, expectedBump: conventionalcommits.Fix},
+		{scopes: []string{"deps/runner"}, expectedBump: conventionalcommits.Feature},
+		{scopes: []string{"deps/runner", "deps/runtime"}, expectedBump: conventionalcommits.Fix},
+	}
+
+	for _, test := range tests {
+		rule, ok := classifier.FindRule("deps", test.scopes, false)
+
+		require.True(t, ok, test.scopes)
+		assert.Equal(t, test.expectedBump, rule.Bump, test.scopes)
+	}
+}
+
+func TestParseBump(t *testing.T) {
+	for bump, expectedType := range map[string]conventionalcommits.Type{
+		"none":  conventionalcommits.Chore,
+		"patch": conventionalcommits.Fix,
+		"Minor": conventionalcommits.Feature,
+		"major": conventionalcommits.BreakingChange,
+	} {
+		commitType, err := conventionalcommits.ParseBump(bump)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedType, commitType)
+	}
+
+	_, err := conventionalcommits.ParseBump("huge")
+	assert.Error(t, err)
+}
@@ -0,0 +1,49 @@
+package git
+
+import (
+	"context"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// GetCommitsInRange returns the commits that are reachable from the to
+// revision but not from the from revision, newest first, like git log from..to.
+// Without from revision, all commits reachable from to are returned.
+func GetCommitsInRange(ctx context.Context, repository *git.Repository, from, to string) ([]*object.Commit, error) {
+	toHash, err := ResolveCommit(repository, to)
+	if err != nil {
+		return nil, err
+	}
+	toCommit, err := repository.CommitObject(toHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var excluded map[plumbing.Hash]bool
+	if from != "" {
+		fromHash, err := ResolveCommit(repository, from)
+		if err != nil {
+			return nil, err
+		}
+		excluded, err = newCommitGraph(repository).ancestorsOf(ctx, fromHash)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var commits []*object.Commit
+	err = object.NewCommitIterCTime(toCommit, excluded, nil).ForEach(func(commit *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		commits = append(commits, commit)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return commits, nil
+}
@@ -0,0 +1,47 @@
+package git_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+func TestGetCommitsInRange(t *testing.T) {
+	repository := createGraphRepository(t, []graphCommit{
+		{id: "a", message: "chore: initial", tag: "v1.0.0"},
+		{id: "b", message: "feat: main", parents: []string{"a"}, offset: time.Hour},
+		{id: "c", message: "fix: side", parents: []string{"a"}, offset: 2 * time.Hour},
+		{id: "m", message: "chore: merge", parents: []string{"b", "c"}, offset: 3 * time.Hour},
+	})
+
+	tests := []struct {
+		from             string
+		to               string
+		expectedSubjects []string
+	}{
+		{from: "v1.0.0", to: "HEAD", expectedSubjects: []string{"chore: merge", "fix: side", "feat: main"}},
+		{from: "HEAD~1", to: "HEAD", expectedSubjects: []string{"chore: merge", "fix: side"}},
+		{from: "", to: "HEAD~1", expectedSubjects: []string{"feat: main", "chore: initial"}},
+		{from: "HEAD", to: "v1.0.0", expectedSubjects: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.from+".."+test.to, func(t *testing.T) {
+			commits, err := git.GetCommitsInRange(context.Background(), repository, test.from, test.to)
+			require.NoError(t, err)
+
+			var subjects []string
+			for _, commit := range commits {
+				subjects = append(subjects, commit.Message)
+			}
+			assert.Equal(t, test.expectedSubjects, subjects)
+		})
+	}
+
+	_, err := git.GetCommitsInRange(context.Background(), repository, "unknown", "HEAD")
+	assert.ErrorContains(t, err, `cannot resolve revision "unknown"`)
+}
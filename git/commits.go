@@ -1,25 +1,103 @@
 package git
 
 import (
+	"context"
 	"errors"
-	"github.com/tvcsantos/get-next-version/util"
-	"io"
+	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/Masterminds/semver"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/util"
 )
 
+type AnalyzedCommit struct {
+	Hash           plumbing.Hash
+	Author         string
+	Subject        string
+	Message        string
+	ParsedCommit   conventionalcommits.ParsedCommit
+	Classification conventionalcommits.Classification
+	// NonConventional is set for commits that are not conventional commits,
+	// apart from merge commits, which are always treated as chores.
+	NonConventional bool
+	// ReleaseAs is the version requested by a Release-As footer, if any.
+	ReleaseAs *semver.Version
+	// Reverts and RevertedBy link a revert commit and the commits it reverts
+	// when they are part of the same release and cancel each other out.
+	Reverts    []plumbing.Hash
+	RevertedBy plumbing.Hash
+	Included   bool
+	Reason     string
+}
+
+type RevertPair struct {
+	Revert   plumbing.Hash
+	Reverted plumbing.Hash
+}
+
 type ConventionalCommitTypesResult struct {
 	LatestReleaseVersion    *semver.Version
+	LatestReleaseTag        string
+	LatestReleaseCommit     plumbing.Hash
+	HeadCommit              plumbing.Hash
 	ConventionalCommitTypes []conventionalcommits.Type
+	Commits                 []AnalyzedCommit
+	Tags                    ReleaseTags
+	// ReleaseAs is the version requested by the most recent included commit
+	// with a Release-As footer, nil when there is none.
+	ReleaseAs       *semver.Version
+	ReleaseAsCommit plumbing.Hash
+	Reverts         []RevertPair
 }
 
-var ErrNoCommitsFound = errors.New("no commits found")
+const ReleaseAsFooterToken = "Release-As"
+
+var (
+	ErrNoCommitsFound   = errors.New("no commits found")
+	ErrInvalidReleaseAs = errors.New("invalid Release-As version")
+)
+
+// Track describes one versioned unit of a repository, e.g. a single package
+// of a monorepo, with its own tags and paths.
+type Track struct {
+	CommitsFilterPathRegex []util.PathFilterRegex
+	// ScopeFilter restricts the commits to those with matching scopes, in
+	// addition to CommitsFilterPathRegex. Without filter, scopes are ignored.
+	ScopeFilter *conventionalcommits.ScopeFilter
+	// SkipRules exclude commits before any other filter.
+	SkipRules *SkipRules
+	// CommitParser parses the commit messages, it defaults to Conventional
+	// Commits. Tracks with the same parser share the analysis of each commit.
+	CommitParser    conventionalcommits.CommitParser
+	TagsFilterRegex *regexp.Regexp
+	VersionRegex    *regexp.Regexp
+	// ParseVersion parses the versions of the tags, it defaults to
+	// semver.NewVersion.
+	ParseVersion   VersionParser
+	InitialVersion *semver.Version
+	// Head is the commit to compute the next version for, it defaults to
+	// HEAD. Baseline replaces the latest release as the start of the
+	// commits, the version is still taken from the latest release at or
+	// before it.
+	Head     plumbing.Hash
+	Baseline plumbing.Hash
+	// HighestBaseline uses the highest release reachable from head as the
+	// latest release, instead of the highest of the nearest ones, see
+	// findBaseline.
+	HighestBaseline bool
+	// BaselineFilter restricts the releases that may become the latest
+	// release, e.g. to the version range of a maintenance branch. The other
+	// tags are still reported in the Tags of the result.
+	BaselineFilter func(version *semver.Version) bool
+}
 
 func GetConventionalCommitTypesSinceLastRelease(
+	ctx context.Context,
 	repository *git.Repository,
 	classifier *conventionalcommits.TypeClassifier,
 	commitsFilterPathRegex []util.PathFilterRegex,
@@ -27,71 +105,427 @@ func GetConventionalCommitTypesSinceLastRelease(
 	versionRegex *regexp.Regexp,
 	initialVersion *semver.Version,
 ) (ConventionalCommitTypesResult, error) {
-	tags, err := GetAllSemVerTags(repository, tagsFilterRegex, versionRegex)
+	results, err := GetConventionalCommitTypesSinceLastReleaseForTracks(ctx, repository, classifier, []Track{{
+		CommitsFilterPathRegex: commitsFilterPathRegex,
+		TagsFilterRegex:        tagsFilterRegex,
+		VersionRegex:           versionRegex,
+		InitialVersion:         initialVersion,
+	}})
 	if err != nil {
 		return ConventionalCommitTypesResult{}, err
 	}
-	head, err := repository.Head()
-	if err != nil {
-		if err == plumbing.ErrReferenceNotFound {
-			return ConventionalCommitTypesResult{}, ErrNoCommitsFound
+
+	return results[0], nil
+}
+
+// GetConventionalCommitTypesSinceLastReleaseForTracks analyzes the commits
+// since the latest release of every track. The commit graph and the analysis
+// of each commit are shared between tracks, so every commit is only decoded,
+// classified and diffed once. The results are returned in the order of the
+// tracks.
+func GetConventionalCommitTypesSinceLastReleaseForTracks(
+	ctx context.Context,
+	repository *git.Repository,
+	classifier *conventionalcommits.TypeClassifier,
+	tracks []Track,
+) ([]ConventionalCommitTypesResult, error) {
+	var repositoryHead plumbing.Hash
+	for _, track := range tracks {
+		if !track.Head.IsZero() {
+			continue
 		}
-		return ConventionalCommitTypesResult{}, err
+		head, err := repository.Head()
+		if err != nil {
+			if err == plumbing.ErrReferenceNotFound {
+				return nil, ErrNoCommitsFound
+			}
+			return nil, err
+		}
+		repositoryHead = head.Hash()
+		break
 	}
-	commitIterator, err := repository.Log(&git.LogOptions{
-		From:  head.Hash(),
-		Order: git.LogOrderCommitterTime,
-		PathFilter: func(path string) bool {
-			if len(commitsFilterPathRegex) <= 0 {
-				return true
+
+	graph := newCommitGraph(repository)
+	defaultParser := conventionalcommits.NewConventionalCommitParser()
+	analyzersByParser := make(map[conventionalcommits.CommitParser]map[plumbing.Hash]*commitAnalyzer)
+
+	results := make([]ConventionalCommitTypesResult, len(tracks))
+	for i, track := range tracks {
+		head := track.Head
+		if head.IsZero() {
+			head = repositoryHead
+		}
+		headCommit, err := repository.CommitObject(head)
+		if err != nil {
+			return nil, err
+		}
+
+		parseVersion := track.ParseVersion
+		if parseVersion == nil {
+			parseVersion = semver.NewVersion
+		}
+		parser := track.CommitParser
+		if parser == nil {
+			parser = defaultParser
+		}
+		analyzers, ok := analyzersByParser[parser]
+		if !ok {
+			analyzers = make(map[plumbing.Hash]*commitAnalyzer)
+			analyzersByParser[parser] = analyzers
+		}
+		tags, err := GetAllReleaseTagsWithParser(repository, track.TagsFilterRegex, track.VersionRegex, parseVersion)
+		if err != nil {
+			return nil, err
+		}
+		results[i] = ConventionalCommitTypesResult{
+			LatestReleaseVersion:    track.InitialVersion,
+			HeadCommit:              head,
+			ConventionalCommitTypes: []conventionalcommits.Type{},
+			Tags:                    tags,
+		}
+
+		searchFrom := head
+		if !track.Baseline.IsZero() {
+			searchFrom = track.Baseline
+		}
+		baselineCommit, hasBaseline, err := findBaseline(ctx, graph, searchFrom, tags, track)
+		if err != nil {
+			return nil, err
+		}
+		if hasBaseline {
+			results[i].LatestReleaseVersion = tags[baselineCommit].Version
+			results[i].LatestReleaseTag = tags[baselineCommit].Name
+			results[i].LatestReleaseCommit = baselineCommit
+		}
+		if !track.Baseline.IsZero() {
+			baselineCommit, hasBaseline = track.Baseline, true
+			results[i].LatestReleaseCommit = baselineCommit
+		}
+
+		var releasedCommits map[plumbing.Hash]bool
+		if hasBaseline {
+			releasedCommits, err = graph.ancestorsOf(ctx, baselineCommit)
+			if err != nil {
+				return nil, err
 			}
-			for _, regex := range commitsFilterPathRegex {
-				localMatch := regex.Regex.MatchString(path)
-				if regex.Exclude {
-					localMatch = !localMatch
-				}
-				if localMatch {
-					return true
-				}
+		}
+
+		// Only commits that are reachable from HEAD but not from the baseline
+		// belong to the next release.
+		commitIterator := object.NewCommitIterCTime(headCommit, releasedCommits, nil)
+		err = commitIterator.ForEach(func(commit *object.Commit) error {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+
+			analyzer, ok := analyzers[commit.Hash]
+			if !ok {
+				analyzer = newCommitAnalyzer(commit, parser, classifier)
+				analyzers[commit.Hash] = analyzer
+			}
+
+			analyzedCommit, err := analyzer.analyze(track)
+			if err != nil {
+				return err
 			}
+			results[i].Commits = append(results[i].Commits, analyzedCommit)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		results[i].Reverts = cancelReverts(results[i].Commits, analyzers)
+		for _, analyzedCommit := range results[i].Commits {
+			if !analyzedCommit.Included {
+				continue
+			}
+			results[i].ConventionalCommitTypes = append(
+				results[i].ConventionalCommitTypes,
+				analyzedCommit.Classification.Type,
+			)
+			// Commits are visited newest first, so the first footer found wins.
+			if analyzedCommit.ReleaseAs != nil && results[i].ReleaseAs == nil {
+				results[i].ReleaseAs = analyzedCommit.ReleaseAs
+				results[i].ReleaseAsCommit = analyzedCommit.Hash
+			}
+		}
+	}
+
+	return results, nil
+}
+
+/*
+findBaseline finds the latest release among the ancestors of head using the
+commit graph rather than commit dates:
+  - walk from head and stop at every commit with a stable release tag
+  - drop the tagged commits that are ancestors of other tagged commits found
+  - choose the highest version, and the nearest commit among equal versions
+
+With Track.HighestBaseline, the walk does not stop at tagged commits and the highest
+version of all ancestors is chosen instead, so that e.g. a hotfix tag placed
+after a newer release does not become the baseline.
+
+Pre-releases are never used as baseline, so that the next version is always
+calculated from the latest stable release. Neither are the releases rejected by
+Track.BaselineFilter.
+*/
+func findBaseline(ctx context.Context, graph *commitGraph, head plumbing.Hash, tags ReleaseTags, track Track) (plumbing.Hash, bool, error) {
+	isRelease := func(hash plumbing.Hash) bool {
+		releaseTag, isTagged := tags[hash]
+		if !isTagged || releaseTag.Version.Prerelease() != "" {
 			return false
-		},
-	})
+		}
+		return track.BaselineFilter == nil || track.BaselineFilter(releaseTag.Version)
+	}
+	if track.HighestBaseline {
+		return findHighestBaseline(ctx, graph, head, tags, isRelease)
+	}
+
+	candidates, err := graph.findTaggedFrontier(ctx, head, isRelease)
+	if err != nil || len(candidates) == 0 {
+		return plumbing.ZeroHash, false, err
+	}
+
+	isSuperseded := make(map[plumbing.Hash]bool)
+	if len(candidates) > 1 {
+		for _, candidate := range candidates {
+			ancestors, err := graph.ancestorsOf(ctx, candidate)
+			if err != nil {
+				return plumbing.ZeroHash, false, err
+			}
+			for _, other := range candidates {
+				if other != candidate && ancestors[other] {
+					isSuperseded[other] = true
+				}
+			}
+		}
+	}
+
+	var baseline plumbing.Hash
+	for _, candidate := range candidates {
+		if isSuperseded[candidate] {
+			continue
+		}
+		if baseline.IsZero() || tags[candidate].Version.GreaterThan(tags[baseline].Version) {
+			baseline = candidate
+		}
+	}
+
+	return baseline, true, nil
+}
+
+func findHighestBaseline(ctx context.Context, graph *commitGraph, head plumbing.Hash, tags ReleaseTags, isRelease func(plumbing.Hash) bool) (plumbing.Hash, bool, error) {
+	ancestors, err := graph.ancestorsOf(ctx, head)
 	if err != nil {
-		return ConventionalCommitTypesResult{}, err
+		return plumbing.ZeroHash, false, err
+	}
+	for hash := range graph.boundary {
+		if ancestors[hash] {
+			return plumbing.ZeroHash, false, &ShallowRepositoryError{VisibleCommits: len(ancestors)}
+		}
+	}
+
+	var baseline plumbing.Hash
+	for hash := range ancestors {
+		if !isRelease(hash) {
+			continue
+		}
+		if baseline.IsZero() || tags[hash].Version.GreaterThan(tags[baseline].Version) {
+			baseline = hash
+			continue
+		}
+		// Among equal versions, the nearest commit wins.
+		if tags[hash].Version.Equal(tags[baseline].Version) {
+			hashAncestors, err := graph.ancestorsOf(ctx, hash)
+			if err != nil {
+				return plumbing.ZeroHash, false, err
+			}
+			if hashAncestors[baseline] {
+				baseline = hash
+			}
+		}
+	}
+
+	return baseline, !baseline.IsZero(), nil
+}
+
+// commitAnalyzer parses, classifies and diffs a commit against its parents at
+// most once, no matter how many tracks look at it.
+type commitAnalyzer struct {
+	commit         *object.Commit
+	parser         conventionalcommits.CommitParser
+	classifier     *conventionalcommits.TypeClassifier
+	parsedCommit   conventionalcommits.ParsedCommit
+	classification *conventionalcommits.Classification
+	isConventional bool
+	releaseAs      *semver.Version
+	releaseAsErr   error
+	revertedRefs   []string
+	parentChanges  []object.Changes
+}
+
+func newCommitAnalyzer(commit *object.Commit, parser conventionalcommits.CommitParser, classifier *conventionalcommits.TypeClassifier) *commitAnalyzer {
+	return &commitAnalyzer{commit: commit, parser: parser, classifier: classifier}
+}
+
+func (a *commitAnalyzer) analyze(track Track) (AnalyzedCommit, error) {
+	if a.classification == nil {
+		a.parsedCommit, _ = a.parser.Parse(a.commit.Message)
+		a.releaseAs, a.releaseAsErr = parseReleaseAs(a.commit.Hash, a.parsedCommit)
+		a.revertedRefs = findRevertedRefs(a.commit.Message, a.parsedCommit)
+
+		classification, err := conventionalcommits.ClassifyParsedCommit(a.parsedCommit, a.classifier)
+		a.isConventional = err == nil
+		// Merge and revert commits are usually generated by git or the forge,
+		// so they are chores rather than non-conventional commits.
+		if !a.isConventional {
+			switch {
+			case a.commit.NumParents() > 1:
+				classification.Type = conventionalcommits.Chore
+				classification.Reason = "merge commit → chore"
+				a.isConventional = true
+			case len(a.revertedRefs) > 0:
+				classification.Type = conventionalcommits.Chore
+				classification.Reason = "revert → chore"
+				a.isConventional = true
+			}
+		}
+		a.classification = &classification
+	}
+
+	analyzedCommit := AnalyzedCommit{
+		Hash:            a.commit.Hash,
+		Author:          a.commit.Author.String(),
+		Subject:         strings.SplitN(a.commit.Message, "\n", 2)[0],
+		Message:         a.commit.Message,
+		ParsedCommit:    a.parsedCommit,
+		Classification:  *a.classification,
+		NonConventional: !a.isConventional,
+		ReleaseAs:       a.releaseAs,
+		Included:        true,
+		Reason:          a.classification.Reason,
+	}
+
+	if rule, isSkipped := track.SkipRules.findRule(a.commit, a.parsedCommit); isSkipped {
+		analyzedCommit.Included = false
+		analyzedCommit.Reason = "skipped by rule " + rule
+	} else if track.ScopeFilter != nil && !track.ScopeFilter.Matches(a.parsedCommit.Scopes) {
+		analyzedCommit.Included = false
+		analyzedCommit.Reason = "filtered out by scope"
+	} else if len(track.CommitsFilterPathRegex) > 0 {
+		if a.parentChanges == nil {
+			var err error
+			a.parentChanges, err = diffAgainstParents(a.commit)
+			if err != nil {
+				return AnalyzedCommit{}, err
+			}
+		}
+		if !matchesPathFilter(a.parentChanges, track.CommitsFilterPathRegex) {
+			analyzedCommit.Included = false
+			analyzedCommit.Reason = "filtered out by path"
+		}
+	}
+
+	// A malformed footer only matters for commits that are part of the release.
+	if analyzedCommit.Included && a.releaseAsErr != nil {
+		return AnalyzedCommit{}, a.releaseAsErr
+	}
+
+	return analyzedCommit, nil
+}
+
+func parseReleaseAs(hash plumbing.Hash, parsedCommit conventionalcommits.ParsedCommit) (*semver.Version, error) {
+	footer, ok := parsedCommit.Footer(ReleaseAsFooterToken)
+	if !ok {
+		return nil, nil
+	}
+
+	value := strings.TrimSpace(footer.Value)
+	version, err := semver.NewVersion(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w %+q in commit %s: %v", ErrInvalidReleaseAs, value, hash, err)
 	}
 
-	currentCommit, currentCommitErr := commitIterator.Next()
-	var latestReleaseVersion *semver.Version
-	conventionalCommitTypes := []conventionalcommits.Type{}
-	for currentCommitErr == nil {
-		var doesVersionExistForCommit bool
-		latestReleaseVersion, doesVersionExistForCommit = tags[currentCommit.Hash]
-		if doesVersionExistForCommit {
-			break
+	return version, nil
+}
+
+// diffAgainstParents returns the changes of the commit compared to each of its
+// parents, or to the empty tree for root commits.
+func diffAgainstParents(commit *object.Commit) ([]object.Changes, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	if commit.NumParents() == 0 {
+		changes, err := object.DiffTree(nil, tree)
+		if err != nil {
+			return nil, err
 		}
+		return []object.Changes{changes}, nil
+	}
 
-		currentCommitType, err := conventionalcommits.CommitMessageToTypeWithClassifier(currentCommit.Message, classifier)
+	parentChanges := make([]object.Changes, 0, commit.NumParents())
+	parents := commit.Parents()
+	defer parents.Close()
+	err = parents.ForEach(func(parent *object.Commit) error {
+		parentTree, err := parent.Tree()
 		if err != nil {
-			currentCommitType = conventionalcommits.Chore
+			return err
 		}
-		conventionalCommitTypes = append(
-			conventionalCommitTypes,
-			currentCommitType,
-		)
-		currentCommit, currentCommitErr = commitIterator.Next()
+		changes, err := object.DiffTree(parentTree, tree)
+		if err != nil {
+			return err
+		}
+		parentChanges = append(parentChanges, changes)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if currentCommitErr != nil {
-		if currentCommitErr != io.EOF {
-			return ConventionalCommitTypesResult{}, currentCommitErr
+	return parentChanges, nil
+}
+
+// matchesPathFilter reports whether the commit changes a matching path
+// compared to each of its parents, so merges that only bring in changes
+// already made on the merged branch are not counted twice.
+func matchesPathFilter(parentChanges []object.Changes, commitsFilterPathRegex []util.PathFilterRegex) bool {
+	for _, changes := range parentChanges {
+		if !hasMatchingChange(changes, commitsFilterPathRegex) {
+			return false
 		}
+	}
+
+	return true
+}
 
-		latestReleaseVersion = initialVersion
+func hasMatchingChange(changes object.Changes, commitsFilterPathRegex []util.PathFilterRegex) bool {
+	for _, change := range changes {
+		for _, path := range []string{change.From.Name, change.To.Name} {
+			if path != "" && matchesPath(path, commitsFilterPathRegex) {
+				return true
+			}
+		}
 	}
 
-	return ConventionalCommitTypesResult{
-		LatestReleaseVersion:    latestReleaseVersion,
-		ConventionalCommitTypes: conventionalCommitTypes,
-	}, nil
+	return false
+}
+
+// matchesPath reports whether the path matches at least one include regex and
+// no exclude regex. Without include regexes, every path is included.
+func matchesPath(path string, commitsFilterPathRegex []util.PathFilterRegex) bool {
+	hasInclude, included := false, false
+	for _, regex := range commitsFilterPathRegex {
+		if regex.Exclude {
+			if regex.Regex.MatchString(path) {
+				return false
+			}
+			continue
+		}
+		hasInclude = true
+		included = included || regex.Regex.MatchString(path)
+	}
+	return !hasInclude || included
 }
@@ -1,14 +1,18 @@
 package git_test
 
 import (
+	"context"
 	"github.com/tvcsantos/get-next-version/util"
 	"os"
 	"path/filepath"
 	"regexp"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver"
 	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tvcsantos/get-next-version/conventionalcommits"
@@ -20,10 +24,59 @@ type commit struct {
 	message string
 	tag     string
 	files   []string // relative fake files associated with the commit
+	author  *object.Signature
 }
 
 var DefaultFiles = []string{"src/main.go", "README.md", "CHANGELOG.md"}
 
+func createRepository(t *testing.T, commitHistory []commit, annotateTags bool) *gogit.Repository {
+	repoDir := t.TempDir()
+	repository, err := gogit.PlainInit(repoDir, false)
+	require.NoError(t, err)
+
+	worktree, err := repository.Worktree()
+	require.NoError(t, err)
+
+	for _, commit := range commitHistory {
+		filePaths := make([]string, len(commit.files))
+		for j, file := range commit.files {
+			filePaths[j] = filepath.Join(repoDir, file)
+			err = os.MkdirAll(filepath.Dir(filePaths[j]), 0755)
+			require.NoError(t, err)
+			err = os.WriteFile(filePaths[j], []byte(commit.message), 0644)
+			require.NoError(t, err)
+			_, err = worktree.Add(file)
+			require.NoError(t, err)
+		}
+
+		commitOptions := testutil.CreateCommitOptions()
+		if commit.author != nil {
+			commitOptions.Author = commit.author
+		}
+		_, err = worktree.Commit(commit.message, commitOptions)
+		require.NoError(t, err)
+
+		if commit.tag == "" {
+			continue
+		}
+
+		head, err := repository.Head()
+		require.NoError(t, err)
+
+		var createTagOpts *gogit.CreateTagOptions
+		if annotateTags {
+			createTagOpts = &gogit.CreateTagOptions{
+				Message: "some message",
+				Tagger:  commitOptions.Author,
+			}
+		}
+		_, err = repository.CreateTag(commit.tag, head.Hash(), createTagOpts)
+		require.NoError(t, err)
+	}
+
+	return repository
+}
+
 func TestGetConventionalCommitTypesSinceLatestRelease(t *testing.T) {
 	tests := []struct {
 		commitHistory                   []commit
@@ -201,49 +254,68 @@ func TestGetConventionalCommitTypesSinceLatestRelease(t *testing.T) {
 			tagsFilterRegex:        "component-.*",
 			versionRegex:           "component-(.*)",
 		},
+		{
+			commitHistory: []commit{
+				{message: "chore: Do something", tag: "1.0.0", files: DefaultFiles},
+				{message: "feat: docs only", tag: "", files: []string{"docs/guide.md"}},
+				{message: "fix: source", tag: "", files: []string{"src/main.go"}},
+			},
+			doExpectError:                   false,
+			expectedLastVersion:             semver.MustParse("1.0.0"),
+			expectedConventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			annotateTags:                    false,
+			commitsFilterPathRegex:          []string{"^src/"},
+			tagsFilterRegex:                 "",
+			versionRegex:                    "",
+		},
+		{
+			commitHistory: []commit{
+				{message: "chore: Do something", tag: "1.0.0", files: DefaultFiles},
+				{message: "feat: docs only", tag: "", files: []string{"docs/guide.md"}},
+				{message: "fix: source", tag: "", files: []string{"src/main.go"}},
+			},
+			doExpectError:                   false,
+			expectedLastVersion:             semver.MustParse("1.0.0"),
+			expectedConventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			annotateTags:                    false,
+			commitsFilterPathRegex:          []string{"!^docs/"},
+			tagsFilterRegex:                 "",
+			versionRegex:                    "",
+		},
+		{
+			commitHistory: []commit{
+				{message: "chore: Do something", tag: "1.0.0", files: DefaultFiles},
+				{message: "feat: docs only", tag: "", files: []string{"docs/guide.md"}},
+				{message: "feat: generated source", tag: "", files: []string{"src/gen/api.go"}},
+				{message: "fix: source", tag: "", files: []string{"src/main.go"}},
+			},
+			doExpectError:                   false,
+			expectedLastVersion:             semver.MustParse("1.0.0"),
+			expectedConventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			annotateTags:                    false,
+			commitsFilterPathRegex:          []string{"^src/", "!^src/gen/"},
+			tagsFilterRegex:                 "",
+			versionRegex:                    "",
+		},
+		{
+			commitHistory: []commit{
+				{message: "chore: Do something", tag: "v1.0.0", files: DefaultFiles},
+				{message: "feat: new feature", tag: "v1.1.0-rc.1", files: DefaultFiles},
+				{message: "fix: a fix", tag: "", files: DefaultFiles},
+			},
+			doExpectError:                   false,
+			expectedLastVersion:             semver.MustParse("1.0.0"),
+			expectedConventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Feature, conventionalcommits.Fix},
+			annotateTags:                    false,
+			commitsFilterPathRegex:          nil,
+			tagsFilterRegex:                 "",
+			versionRegex:                    "",
+		},
 	}
 
 	for _, test := range tests {
-		repoDir := t.TempDir()
-		repository, err := gogit.PlainInit(repoDir, false)
-		require.NoError(t, err)
-
-		worktree, err := repository.Worktree()
-		require.NoError(t, err)
-
-		for _, commit := range test.commitHistory {
-			filePaths := make([]string, len(commit.files))
-			for j, file := range commit.files {
-				filePaths[j] = filepath.Join(repoDir, file)
-				err = os.MkdirAll(filepath.Dir(filePaths[j]), 0755)
-				require.NoError(t, err)
-				err = os.WriteFile(filePaths[j], []byte(commit.message), 0644)
-				require.NoError(t, err)
-				_, err = worktree.Add(file)
-				require.NoError(t, err)
-			}
-
-			commitOptions := testutil.CreateCommitOptions()
-			_, err = worktree.Commit(commit.message, commitOptions)
-			require.NoError(t, err)
-
-			if commit.tag == "" {
-				continue
-			}
-
-			head, err := repository.Head()
-			require.NoError(t, err)
-
-			var createTagOpts *gogit.CreateTagOptions
-			if test.annotateTags {
-				createTagOpts = &gogit.CreateTagOptions{
-					Message: "some message",
-					Tagger:  commitOptions.Author,
-				}
-			}
-			_, err = repository.CreateTag(commit.tag, head.Hash(), createTagOpts)
-			require.NoError(t, err)
-		}
+		repository := createRepository(t, test.commitHistory, test.annotateTags)
+		var err error
 
 		var versionRegex *regexp.Regexp
 		var commitsFilterPathRegex []util.PathFilterRegex
@@ -267,6 +339,7 @@ func TestGetConventionalCommitTypesSinceLatestRelease(t *testing.T) {
 
 		classifier := conventionalcommits.NewTypeClassifier()
 		actual, err := git.GetConventionalCommitTypesSinceLastRelease(
+			context.Background(),
 			repository,
 			classifier,
 			commitsFilterPathRegex,
@@ -290,3 +363,511 @@ func TestGetConventionalCommitTypesSinceLatestRelease(t *testing.T) {
 		assert.ElementsMatch(t, test.expectedConventionalCommitTypes, actual.ConventionalCommitTypes)
 	}
 }
+
+func TestGetConventionalCommitTypesSinceLatestReleaseReportsCommits(t *testing.T) {
+	repository := createRepository(t, []commit{
+		{message: "chore: Do something", tag: "v1.0.0", files: DefaultFiles},
+		{message: "feat(api): add endpoint", tag: "", files: []string{"src/api.go"}},
+		{message: "fix: docs only", tag: "", files: []string{"docs/guide.md"}},
+		{message: "Some random message", tag: "", files: []string{"src/main.go"}},
+		{message: "fix: breaking\n\nBREAKING CHANGE: removed option", tag: "", files: []string{"src/main.go"}},
+	}, true)
+
+	filter, err := util.ToPathRegex("^src/")
+	require.NoError(t, err)
+
+	actual, err := git.GetConventionalCommitTypesSinceLastRelease(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		[]util.PathFilterRegex{filter},
+		nil,
+		nil,
+		semver.MustParse("0.0.0"),
+	)
+	require.NoError(t, err)
+
+	head, err := repository.Head()
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.0.0", actual.LatestReleaseTag)
+	assert.False(t, actual.LatestReleaseCommit.IsZero())
+	assert.Equal(t, head.Hash(), actual.HeadCommit)
+	require.Len(t, actual.Commits, 4)
+
+	reasons := map[string]string{}
+	included := map[string]bool{}
+	for _, analyzedCommit := range actual.Commits {
+		reasons[analyzedCommit.Subject] = analyzedCommit.Reason
+		included[analyzedCommit.Subject] = analyzedCommit.Included
+	}
+
+	assert.Equal(t, map[string]string{
+		"feat(api): add endpoint": "type feat → feature",
+		"fix: docs only":          "filtered out by path",
+		"Some random message":     "unparseable → chore",
+		"fix: breaking":           "footer BREAKING CHANGE",
+	}, reasons)
+	assert.Equal(t, map[string]bool{
+		"feat(api): add endpoint": true,
+		"fix: docs only":          false,
+		"Some random message":     true,
+		"fix: breaking":           true,
+	}, included)
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseForTracksFiltersScopes(t *testing.T) {
+	repository := createRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0", files: DefaultFiles},
+		{message: "feat(web): new page", tag: "", files: []string{"src/web.go"}},
+		{message: "fix(api): docs", tag: "", files: []string{"docs/api.md"}},
+		{message: "fix(api): a bug", tag: "", files: []string{"src/api.go"}},
+	}, false)
+
+	pathFilter, err := util.ToPathRegex("^src/")
+	require.NoError(t, err)
+	scopeFilter, err := conventionalcommits.NewScopeFilter([]string{"api"}, nil, true)
+	require.NoError(t, err)
+
+	results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		[]git.Track{{
+			CommitsFilterPathRegex: []util.PathFilterRegex{pathFilter},
+			ScopeFilter:            scopeFilter,
+			InitialVersion:         semver.MustParse("0.0.0"),
+		}},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []conventionalcommits.Type{conventionalcommits.Fix}, results[0].ConventionalCommitTypes)
+	assert.Equal(t, "filtered out by scope", results[0].Commits[2].Reason)
+	assert.Equal(t, "filtered out by path", results[0].Commits[1].Reason)
+	assert.True(t, results[0].Commits[0].Included)
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseForTracksWithCommitParsers(t *testing.T) {
+	repository := createRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0", files: DefaultFiles},
+		{message: ":sparkles: add endpoint", tag: "", files: DefaultFiles},
+		{message: "fix: a bug", tag: "", files: DefaultFiles},
+	}, false)
+
+	results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		[]git.Track{
+			{InitialVersion: semver.MustParse("0.0.0")},
+			{CommitParser: conventionalcommits.NewGitmojiParser(), InitialVersion: semver.MustParse("0.0.0")},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, []conventionalcommits.Type{conventionalcommits.Fix, conventionalcommits.Chore}, results[0].ConventionalCommitTypes)
+	assert.True(t, results[0].Commits[1].NonConventional)
+	assert.Equal(t, []conventionalcommits.Type{conventionalcommits.Chore, conventionalcommits.Feature}, results[1].ConventionalCommitTypes)
+	assert.True(t, results[1].Commits[0].NonConventional)
+	assert.Equal(t, "feat", results[1].Commits[1].ParsedCommit.Type)
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseForTracksSkipsCommits(t *testing.T) {
+	bot := &object.Signature{Name: "dependabot[bot]", Email: "49699333+dependabot[bot]@users.noreply.github.com"}
+	repository := createRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0", files: DefaultFiles},
+		{message: "feat: bump lib from 1.0 to 2.0", files: []string{"go.mod"}, author: bot},
+		{message: "feat: release tooling\n\nRelease-Skip: true\nRelease-As: 3.0.0", files: []string{"Makefile"}},
+		{message: "feat: keep me\n\nRelease-Skip: false", files: []string{"src/main.go"}},
+		{message: "feat!: experiment [Skip Release]", files: []string{"src/main.go"}},
+		{message: "fix: a bug", files: []string{"src/main.go"}},
+	}, false)
+
+	results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		[]git.Track{{
+			SkipRules: &git.SkipRules{
+				Authors:  []*regexp.Regexp{regexp.MustCompile(`\[bot\]`)},
+				Trailers: []string{"release-skip: TRUE"},
+				Markers:  []string{"[skip release]"},
+			},
+			InitialVersion: semver.MustParse("0.0.0"),
+		}},
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []conventionalcommits.Type{conventionalcommits.Fix, conventionalcommits.Feature}, results[0].ConventionalCommitTypes)
+	assert.Nil(t, results[0].ReleaseAs)
+	require.Len(t, results[0].Commits, 5)
+	assert.True(t, results[0].Commits[0].Included)
+	assert.Equal(t, "skipped by rule marker [skip release]", results[0].Commits[1].Reason)
+	assert.True(t, results[0].Commits[2].Included)
+	assert.Equal(t, "skipped by rule trailer release-skip: TRUE", results[0].Commits[3].Reason)
+	assert.Equal(t, `skipped by rule author \[bot\]`, results[0].Commits[4].Reason)
+	assert.False(t, results[0].Commits[4].Included)
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseForTracksWithRevisions(t *testing.T) {
+	repository := createGraphRepository(t, []graphCommit{
+		{id: "a", message: "chore: initial", tag: "v1.0.0"},
+		{id: "b", message: "feat: a feature", parents: []string{"a"}, offset: time.Hour},
+		{id: "c", message: "fix: a bug", parents: []string{"b"}, offset: 2 * time.Hour},
+	})
+	first, err := git.ResolveCommit(repository, "v1.0.0")
+	require.NoError(t, err)
+	second, err := git.ResolveCommit(repository, "HEAD~1")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name             string
+		track            git.Track
+		expectedTag      string
+		expectedVersion  string
+		expectedBaseline plumbing.Hash
+		expectedSubjects []string
+	}{
+		{
+			name:             "head",
+			track:            git.Track{Head: second},
+			expectedTag:      "v1.0.0",
+			expectedVersion:  "1.0.0",
+			expectedBaseline: first,
+			expectedSubjects: []string{"feat: a feature"},
+		},
+		{
+			name:             "baseline",
+			track:            git.Track{Baseline: second},
+			expectedTag:      "v1.0.0",
+			expectedVersion:  "1.0.0",
+			expectedBaseline: second,
+			expectedSubjects: []string{"fix: a bug"},
+		},
+		{
+			name:             "baseline without release tags",
+			track:            git.Track{Baseline: first, TagsFilterRegex: regexp.MustCompile(`^release-`)},
+			expectedVersion:  "0.1.0",
+			expectedBaseline: first,
+			expectedSubjects: []string{"fix: a bug", "feat: a feature"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.track.InitialVersion = semver.MustParse("0.1.0")
+			results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(context.Background(), repository, conventionalcommits.NewTypeClassifier(), []git.Track{test.track})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedTag, results[0].LatestReleaseTag)
+			assert.Equal(t, test.expectedVersion, results[0].LatestReleaseVersion.String())
+			assert.Equal(t, test.expectedBaseline, results[0].LatestReleaseCommit)
+			var subjects []string
+			for _, commit := range results[0].Commits {
+				subjects = append(subjects, commit.Subject)
+			}
+			assert.Equal(t, test.expectedSubjects, subjects)
+		})
+	}
+}
+
+func TestGetConventionalCommitTypesSinceLatestReleaseDetectsReleaseAs(t *testing.T) {
+	repository := createRepository(t, []commit{
+		{message: "chore: Do something", tag: "v1.0.0", files: DefaultFiles},
+		{message: "fix: align\n\nRelease-As: 2.0.0", tag: "", files: []string{"src/main.go"}},
+		{message: "docs: skip\n\nrelease-as: v3.0.0", tag: "", files: []string{"src/main.go"}},
+		{message: "docs: filtered\n\nRelease-As: 4.0.0", tag: "", files: []string{"docs/guide.md"}},
+	}, false)
+
+	filter, err := util.ToPathRegex("^src/")
+	require.NoError(t, err)
+
+	actual, err := git.GetConventionalCommitTypesSinceLastRelease(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		[]util.PathFilterRegex{filter},
+		nil,
+		nil,
+		semver.MustParse("0.0.0"),
+	)
+	require.NoError(t, err)
+
+	require.NotNil(t, actual.ReleaseAs)
+	assert.Equal(t, "3.0.0", actual.ReleaseAs.String())
+	require.Len(t, actual.Commits, 3)
+	assert.Equal(t, actual.Commits[1].Hash, actual.ReleaseAsCommit)
+
+	repository = createRepository(t, []commit{
+		{message: "chore: Do something", tag: "v1.0.0", files: DefaultFiles},
+		{message: "fix: typo\n\nRelease-As: latest", tag: "", files: []string{"src/main.go"}},
+	}, false)
+	_, err = git.GetConventionalCommitTypesSinceLastRelease(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		nil,
+		nil,
+		nil,
+		semver.MustParse("0.0.0"),
+	)
+	assert.ErrorIs(t, err, git.ErrInvalidReleaseAs)
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseForTracks(t *testing.T) {
+	repository := createRepository(t, []commit{
+		{message: "chore: initial", tag: "", files: []string{"README.md"}},
+		{message: "feat: add foo", tag: "foo/v1.0.0", files: []string{"foo/main.go"}},
+		{message: "fix: fix bar", tag: "", files: []string{"bar/main.go"}},
+		{message: "feat: improve foo", tag: "", files: []string{"foo/main.go"}},
+	}, false)
+
+	fooFilter, err := util.ToPathRegex("^foo/")
+	require.NoError(t, err)
+	barFilter, err := util.ToPathRegex("^bar/")
+	require.NoError(t, err)
+
+	results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		[]git.Track{
+			{
+				CommitsFilterPathRegex: []util.PathFilterRegex{fooFilter},
+				TagsFilterRegex:        regexp.MustCompile(`^foo/v`),
+				VersionRegex:           regexp.MustCompile(`^foo/v(.+)$`),
+				InitialVersion:         semver.MustParse("0.0.0"),
+			},
+			{
+				CommitsFilterPathRegex: []util.PathFilterRegex{barFilter},
+				TagsFilterRegex:        regexp.MustCompile(`^bar/v`),
+				VersionRegex:           regexp.MustCompile(`^bar/v(.+)$`),
+				InitialVersion:         semver.MustParse("0.0.0"),
+			},
+		},
+	)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	assert.Equal(t, "foo/v1.0.0", results[0].LatestReleaseTag)
+	assert.Equal(t, "1.0.0", results[0].LatestReleaseVersion.String())
+	assert.Equal(t, []conventionalcommits.Type{conventionalcommits.Feature}, results[0].ConventionalCommitTypes)
+	assert.Len(t, results[0].Commits, 2)
+
+	assert.Equal(t, "", results[1].LatestReleaseTag)
+	assert.Equal(t, "0.0.0", results[1].LatestReleaseVersion.String())
+	assert.Equal(t, []conventionalcommits.Type{conventionalcommits.Fix}, results[1].ConventionalCommitTypes)
+	assert.Len(t, results[1].Commits, 4)
+}
+
+type graphCommit struct {
+	id      string
+	message string
+	parents []string
+	tag     string
+	offset  time.Duration // committer date relative to a fixed point in time
+}
+
+// createGraphRepository creates a repository with the given commit graph and
+// checks out the last commit. Commits must be listed after their parents.
+func createGraphRepository(t *testing.T, commits []graphCommit) *gogit.Repository {
+	repository, err := testutil.SetUpInMemoryRepository()
+	require.NoError(t, err)
+
+	baseTime := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	hashes := make(map[string]plumbing.Hash, len(commits))
+	for _, graphCommit := range commits {
+		var parents []plumbing.Hash
+		for _, parent := range graphCommit.parents {
+			parents = append(parents, hashes[parent])
+		}
+
+		hashes[graphCommit.id], err = testutil.CreateCommitObject(repository, graphCommit.message, parents, baseTime.Add(graphCommit.offset))
+		require.NoError(t, err)
+
+		if graphCommit.tag != "" {
+			require.NoError(t, testutil.CreateTag(repository, graphCommit.tag, hashes[graphCommit.id]))
+		}
+	}
+
+	head := hashes[commits[len(commits)-1].id]
+	require.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference(plumbing.Master, head)))
+
+	return repository
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseUsesCommitGraph(t *testing.T) {
+	tests := []struct {
+		name             string
+		commits          []graphCommit
+		expectedTag      string
+		expectedSubjects []string
+	}{
+		{
+			name: "release branch merged back",
+			commits: []graphCommit{
+				{id: "a", message: "chore: initial", tag: "v1.0.0"},
+				{id: "b", message: "feat: main feature", parents: []string{"a"}, offset: 2 * time.Hour},
+				{id: "r", message: "fix: hotfix", parents: []string{"a"}, tag: "v1.0.1", offset: 1 * time.Hour},
+				{id: "m", message: "chore: merge release branch", parents: []string{"b", "r"}, offset: 3 * time.Hour},
+			},
+			expectedTag:      "v1.0.1",
+			expectedSubjects: []string{"chore: merge release branch", "feat: main feature"},
+		},
+		{
+			name: "older tag with a newer committer date",
+			commits: []graphCommit{
+				{id: "a", message: "chore: initial", tag: "v1.0.0", offset: 5 * time.Hour},
+				{id: "b", message: "feat: feature", parents: []string{"a"}, tag: "v1.1.0", offset: 1 * time.Hour},
+				{id: "c", message: "fix: bug", parents: []string{"b"}, offset: 6 * time.Hour},
+			},
+			expectedTag:      "v1.1.0",
+			expectedSubjects: []string{"fix: bug"},
+		},
+		{
+			name: "older tag with a newer committer date across a merge",
+			commits: []graphCommit{
+				{id: "a", message: "chore: initial", tag: "v1.0.0", offset: 5 * time.Hour},
+				{id: "b", message: "feat: feature", parents: []string{"a"}, tag: "v1.1.0", offset: 1 * time.Hour},
+				{id: "x", message: "fix: side branch", parents: []string{"a"}, offset: 6 * time.Hour},
+				{id: "m", message: "chore: merge side branch", parents: []string{"b", "x"}, offset: 7 * time.Hour},
+			},
+			expectedTag:      "v1.1.0",
+			expectedSubjects: []string{"chore: merge side branch", "fix: side branch"},
+		},
+		{
+			name: "unreleased commit with an older committer date than the release",
+			commits: []graphCommit{
+				{id: "a", message: "chore: initial", tag: "v1.0.0", offset: 5 * time.Hour},
+				{id: "b", message: "feat: feature", parents: []string{"a"}, offset: -24 * time.Hour},
+				{id: "c", message: "fix: bug", parents: []string{"b"}, offset: 6 * time.Hour},
+			},
+			expectedTag:      "v1.0.0",
+			expectedSubjects: []string{"fix: bug", "feat: feature"},
+		},
+		{
+			name: "criss-cross merge",
+			commits: []graphCommit{
+				{id: "a", message: "chore: initial", tag: "v1.0.0"},
+				{id: "b1", message: "feat: left", parents: []string{"a"}, offset: 10 * time.Hour},
+				{id: "c1", message: "fix: right", parents: []string{"a"}, offset: 1 * time.Hour},
+				{id: "b2", message: "chore: merge right into left", parents: []string{"b1", "c1"}, offset: 3 * time.Hour},
+				{id: "c2", message: "chore: merge left into right", parents: []string{"c1", "b1"}, tag: "v1.1.0", offset: 2 * time.Hour},
+				{id: "m", message: "chore: final merge", parents: []string{"b2", "c2"}, offset: 11 * time.Hour},
+			},
+			expectedTag:      "v1.1.0",
+			expectedSubjects: []string{"chore: final merge", "chore: merge right into left"},
+		},
+		{
+			name: "highest of unrelated releases",
+			commits: []graphCommit{
+				{id: "a", message: "chore: initial"},
+				{id: "b", message: "fix: old line", parents: []string{"a"}, tag: "v1.0.5", offset: 2 * time.Hour},
+				{id: "c", message: "feat: new line", parents: []string{"a"}, tag: "v1.1.0", offset: 1 * time.Hour},
+				{id: "m", message: "chore: merge old line", parents: []string{"c", "b"}, offset: 3 * time.Hour},
+			},
+			expectedTag:      "v1.1.0",
+			expectedSubjects: []string{"chore: merge old line", "fix: old line"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := createGraphRepository(t, test.commits)
+
+			actual, err := git.GetConventionalCommitTypesSinceLastRelease(
+				context.Background(),
+				repository,
+				conventionalcommits.NewTypeClassifier(),
+				nil,
+				nil,
+				nil,
+				semver.MustParse("0.0.0"),
+			)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedTag, actual.LatestReleaseTag)
+			var subjects []string
+			for _, analyzedCommit := range actual.Commits {
+				subjects = append(subjects, analyzedCommit.Subject)
+			}
+			assert.ElementsMatch(t, test.expectedSubjects, subjects)
+		})
+	}
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseWithHighestBaseline(t *testing.T) {
+	repository := createGraphRepository(t, []graphCommit{
+		{id: "a", message: "chore: initial", tag: "v1.2.4"},
+		{id: "b", message: "feat: a feature", parents: []string{"a"}, tag: "v1.3.0", offset: time.Hour},
+		{id: "c", message: "fix: cherry-picked hotfix", parents: []string{"b"}, tag: "v1.2.5", offset: 2 * time.Hour},
+		{id: "d", message: "fix: a bug", parents: []string{"c"}, offset: 3 * time.Hour},
+	})
+
+	tests := []struct {
+		highestBaseline  bool
+		expectedTag      string
+		expectedSubjects []string
+	}{
+		{highestBaseline: false, expectedTag: "v1.2.5", expectedSubjects: []string{"fix: a bug"}},
+		{highestBaseline: true, expectedTag: "v1.3.0", expectedSubjects: []string{"fix: a bug", "fix: cherry-picked hotfix"}},
+	}
+
+	for _, test := range tests {
+		results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(context.Background(), repository, conventionalcommits.NewTypeClassifier(), []git.Track{{
+			InitialVersion:  semver.MustParse("0.0.0"),
+			HighestBaseline: test.highestBaseline,
+		}})
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expectedTag, results[0].LatestReleaseTag)
+		var subjects []string
+		for _, commit := range results[0].Commits {
+			subjects = append(subjects, commit.Subject)
+		}
+		assert.Equal(t, test.expectedSubjects, subjects)
+	}
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseReportsNonConventionalCommits(t *testing.T) {
+	repository := createGraphRepository(t, []graphCommit{
+		{id: "a", message: "chore: initial", tag: "v1.0.0"},
+		{id: "b", message: "Fix the login page", parents: []string{"a"}, offset: time.Hour},
+		{id: "c", message: "fix: side branch", parents: []string{"a"}, offset: 2 * time.Hour},
+		{id: "m", message: "Merge branch 'side'", parents: []string{"b", "c"}, offset: 3 * time.Hour},
+	})
+
+	classifier := conventionalcommits.NewTypeClassifier()
+	classifier.SetNonConventionalType(conventionalcommits.Fix)
+
+	actual, err := git.GetConventionalCommitTypesSinceLastRelease(
+		context.Background(),
+		repository,
+		classifier,
+		nil,
+		nil,
+		nil,
+		semver.MustParse("0.0.0"),
+	)
+	require.NoError(t, err)
+
+	nonConventional := map[string]bool{}
+	reasons := map[string]string{}
+	for _, analyzedCommit := range actual.Commits {
+		nonConventional[analyzedCommit.Subject] = analyzedCommit.NonConventional
+		reasons[analyzedCommit.Subject] = analyzedCommit.Reason
+	}
+
+	assert.Equal(t, map[string]bool{
+		"Merge branch 'side'": false,
+		"fix: side branch":    false,
+		"Fix the login page":  true,
+	}, nonConventional)
+	assert.Equal(t, map[string]string{
+		"Merge branch 'side'": "merge commit → chore",
+		"fix: side branch":    "type fix → fix",
+		"Fix the login page":  "unparseable → fix",
+	}, reasons)
+	assert.ElementsMatch(t, []conventionalcommits.Type{conventionalcommits.Chore, conventionalcommits.Fix, conventionalcommits.Fix}, actual.ConventionalCommitTypes)
+}
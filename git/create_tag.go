@@ -0,0 +1,145 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+var (
+	ErrTagAlreadyExists = errors.New("tag already exists")
+	ErrMissingTagger    = errors.New("no tagger given and none found in git config (user.name and user.email)")
+)
+
+type CreateTagOptions struct {
+	// Annotated creates a tag object instead of a lightweight tag. Signed
+	// tags are always annotated.
+	Annotated bool
+	Message   string
+	// Tagger defaults to the author or user configured in git config.
+	Tagger *object.Signature
+	Signer git.Signer
+}
+
+func CreateTag(repository *git.Repository, name string, hash plumbing.Hash, options CreateTagOptions) (*plumbing.Reference, error) {
+	referenceName := plumbing.NewTagReferenceName(name)
+	if err := referenceName.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid tag name %+q: %w", name, err)
+	}
+
+	_, err := repository.Storer.Reference(referenceName)
+	switch err {
+	case nil:
+		return nil, fmt.Errorf("%w: %s", ErrTagAlreadyExists, name)
+	case plumbing.ErrReferenceNotFound:
+	default:
+		return nil, err
+	}
+
+	target := hash
+	if options.Annotated || options.Signer != nil {
+		target, err = createTagObject(repository, name, hash, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reference := plumbing.NewHashReference(referenceName, target)
+	if err := repository.Storer.SetReference(reference); err != nil {
+		return nil, err
+	}
+
+	return reference, nil
+}
+
+func createTagObject(repository *git.Repository, name string, hash plumbing.Hash, options CreateTagOptions) (plumbing.Hash, error) {
+	tagger := options.Tagger
+	if tagger == nil {
+		var err error
+		tagger, err = loadConfigTagger(repository)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	message := options.Message
+	if strings.TrimSpace(message) == "" {
+		message = name
+	}
+
+	targetObject, err := object.GetObject(repository.Storer, hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	tag := &object.Tag{
+		Name:       name,
+		Tagger:     *tagger,
+		Message:    strings.TrimSpace(message) + "\n",
+		TargetType: targetObject.Type(),
+		Target:     hash,
+	}
+
+	if options.Signer != nil {
+		unsignedObject := &plumbing.MemoryObject{}
+		if err := tag.EncodeWithoutSignature(unsignedObject); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		reader, err := unsignedObject.Reader()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		signature, err := options.Signer.Sign(reader)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("could not sign tag: %w", err)
+		}
+		tag.PGPSignature = string(signature)
+	}
+
+	encodedObject := repository.Storer.NewEncodedObject()
+	if err := tag.Encode(encodedObject); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return repository.Storer.SetEncodedObject(encodedObject)
+}
+
+func loadConfigTagger(repository *git.Repository) (*object.Signature, error) {
+	cfg, err := repository.ConfigScoped(config.SystemScope)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identity := range []struct{ Name, Email string }{
+		{Name: cfg.Author.Name, Email: cfg.Author.Email},
+		{Name: cfg.User.Name, Email: cfg.User.Email},
+	} {
+		if identity.Name != "" && identity.Email != "" {
+			return &object.Signature{Name: identity.Name, Email: identity.Email, When: time.Now()}, nil
+		}
+	}
+
+	return nil, ErrMissingTagger
+}
+
+func PushTag(ctx context.Context, repository *git.Repository, remoteName string, name string, auth transport.AuthMethod) error {
+	referenceName := plumbing.NewTagReferenceName(name)
+	err := repository.PushContext(ctx, &git.PushOptions{
+		RemoteName: remoteName,
+		RefSpecs:   []config.RefSpec{config.RefSpec(referenceName + ":" + referenceName)},
+		Auth:       auth,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("could not push tag %s to %s: %w", name, remoteName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+package git_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	gogit "github.com/go-git/go-git/v5"
+	gogitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/testutil"
+	"golang.org/x/crypto/ssh"
+)
+
+func setUpRepositoryWithCommit(t *testing.T) (*gogit.Repository, plumbing.Hash) {
+	repository, hashes, err := testutil.SetUpInMemoryRepositoryWithCommits([]testutil.Commit{{Message: "feat: something"}})
+	require.NoError(t, err)
+
+	return repository, hashes[0]
+}
+
+func TestCreateTag(t *testing.T) {
+	tagger := testutil.CreateCommitOptions().Author
+
+	t.Run("creates a lightweight tag", func(t *testing.T) {
+		repository, hash := setUpRepositoryWithCommit(t)
+
+		reference, err := git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, hash, reference.Hash())
+		_, err = repository.TagObject(reference.Hash())
+		assert.Equal(t, plumbing.ErrObjectNotFound, err)
+	})
+
+	t.Run("creates an annotated tag", func(t *testing.T) {
+		repository, hash := setUpRepositoryWithCommit(t)
+
+		reference, err := git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{
+			Annotated: true,
+			Message:   "Release v1.0.0",
+			Tagger:    tagger,
+		})
+		require.NoError(t, err)
+
+		tag, err := repository.TagObject(reference.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, "v1.0.0", tag.Name)
+		assert.Equal(t, "Release v1.0.0\n", tag.Message)
+		assert.Equal(t, hash, tag.Target)
+		assert.Equal(t, tagger.Email, tag.Tagger.Email)
+	})
+
+	t.Run("uses the tagger from git config", func(t *testing.T) {
+		repository, hash := setUpRepositoryWithCommit(t)
+		cfg, err := repository.Config()
+		require.NoError(t, err)
+		cfg.User.Name = "Jane Doe"
+		cfg.User.Email = "jane.doe@example.com"
+		require.NoError(t, repository.SetConfig(cfg))
+
+		reference, err := git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{Annotated: true})
+		require.NoError(t, err)
+
+		tag, err := repository.TagObject(reference.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, "Jane Doe", tag.Tagger.Name)
+		assert.Equal(t, "v1.0.0\n", tag.Message)
+	})
+
+	t.Run("refuses to overwrite an existing tag", func(t *testing.T) {
+		repository, hash := setUpRepositoryWithCommit(t)
+
+		_, err := git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{})
+		require.NoError(t, err)
+
+		_, err = git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{})
+		assert.ErrorIs(t, err, git.ErrTagAlreadyExists)
+	})
+
+	t.Run("rejects invalid tag names", func(t *testing.T) {
+		repository, hash := setUpRepositoryWithCommit(t)
+
+		_, err := git.CreateTag(repository, "v1..0", hash, git.CreateTagOptions{})
+		assert.Error(t, err)
+	})
+
+	t.Run("signs the tag with a GPG key", func(t *testing.T) {
+		repository, hash := setUpRepositoryWithCommit(t)
+		entity, err := openpgp.NewEntity("John Doe", "", "john.doe@example.com", nil)
+		require.NoError(t, err)
+
+		reference, err := git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{
+			Message: "Release v1.0.0",
+			Tagger:  tagger,
+			Signer:  git.NewGPGSigner(entity),
+		})
+		require.NoError(t, err)
+
+		tag, err := repository.TagObject(reference.Hash())
+		require.NoError(t, err)
+
+		var publicKey bytes.Buffer
+		armoredWriter, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+		require.NoError(t, err)
+		require.NoError(t, entity.Serialize(armoredWriter))
+		require.NoError(t, armoredWriter.Close())
+
+		_, err = tag.Verify(publicKey.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("signs the tag with an SSH key", func(t *testing.T) {
+		repository, hash := setUpRepositoryWithCommit(t)
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		signer, err := ssh.NewSignerFromKey(privateKey)
+		require.NoError(t, err)
+
+		reference, err := git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{
+			Message: "Release v1.0.0",
+			Tagger:  tagger,
+			Signer:  git.NewSSHSigner(signer),
+		})
+		require.NoError(t, err)
+
+		tag, err := repository.TagObject(reference.Hash())
+		require.NoError(t, err)
+		verifySSHSignature(t, tag, signer.PublicKey())
+	})
+}
+
+func TestPushTag(t *testing.T) {
+	remoteDirectory := t.TempDir()
+	remote, err := gogit.PlainInit(remoteDirectory, true)
+	require.NoError(t, err)
+
+	repository, hash := setUpRepositoryWithCommit(t)
+	_, err = repository.CreateRemote(&gogitconfig.RemoteConfig{Name: "origin", URLs: []string{remoteDirectory}})
+	require.NoError(t, err)
+
+	_, err = git.CreateTag(repository, "v1.0.0", hash, git.CreateTagOptions{Annotated: true, Tagger: testutil.CreateCommitOptions().Author})
+	require.NoError(t, err)
+
+	err = git.PushTag(context.Background(), repository, "origin", "v1.0.0", nil)
+	require.NoError(t, err)
+
+	reference, err := remote.Tag("v1.0.0")
+	require.NoError(t, err)
+	tag, err := remote.TagObject(reference.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, hash, tag.Target)
+
+	err = git.PushTag(context.Background(), repository, "origin", "v1.0.0", nil)
+	assert.NoError(t, err)
+
+	err = git.PushTag(context.Background(), repository, "non-existent", "v1.0.0", nil)
+	assert.Error(t, err)
+}
+
+func verifySSHSignature(t *testing.T, tag *object.Tag, publicKey ssh.PublicKey) {
+	armored := strings.TrimSpace(tag.PGPSignature)
+	require.True(t, strings.HasPrefix(armored, "-----BEGIN SSH SIGNATURE-----"))
+	require.True(t, strings.HasSuffix(armored, "-----END SSH SIGNATURE-----"))
+
+	lines := strings.Split(armored, "\n")
+	blob, err := base64.StdEncoding.DecodeString(strings.Join(lines[1:len(lines)-1], ""))
+	require.NoError(t, err)
+	require.Equal(t, "SSHSIG", string(blob[:6]))
+
+	var signatureBlob struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}
+	require.NoError(t, ssh.Unmarshal(blob[6:], &signatureBlob))
+	assert.Equal(t, uint32(1), signatureBlob.Version)
+	assert.Equal(t, "git", signatureBlob.Namespace)
+	assert.Equal(t, string(publicKey.Marshal()), signatureBlob.PublicKey)
+
+	var signature ssh.Signature
+	require.NoError(t, ssh.Unmarshal([]byte(signatureBlob.Signature), &signature))
+
+	unsignedObject := &plumbing.MemoryObject{}
+	require.NoError(t, tag.EncodeWithoutSignature(unsignedObject))
+	reader, err := unsignedObject.Reader()
+	require.NoError(t, err)
+	var message bytes.Buffer
+	_, err = message.ReadFrom(reader)
+	require.NoError(t, err)
+
+	hash := sha512.Sum512(message.Bytes())
+	signedData := append([]byte("SSHSIG"), ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{Namespace: "git", HashAlgorithm: "sha512", Hash: string(hash[:])})...)
+
+	assert.NoError(t, publicKey.Verify(signedData, &signature))
+}
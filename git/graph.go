@@ -0,0 +1,142 @@
+package git
+
+import (
+	"context"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// commitGraph caches the parents of the commits it has seen, so that several
+// walks over the same history only decode every commit once. In shallow
+// clones, the history ends at boundary commits whose parents are missing.
+type commitGraph struct {
+	repository *git.Repository
+	parents    map[plumbing.Hash][]plumbing.Hash
+	ancestors  map[plumbing.Hash]map[plumbing.Hash]bool
+	shallow    map[plumbing.Hash]bool
+	boundary   map[plumbing.Hash]bool
+}
+
+func newCommitGraph(repository *git.Repository) *commitGraph {
+	return &commitGraph{
+		repository: repository,
+		parents:    make(map[plumbing.Hash][]plumbing.Hash),
+		ancestors:  make(map[plumbing.Hash]map[plumbing.Hash]bool),
+		boundary:   make(map[plumbing.Hash]bool),
+	}
+}
+
+func (g *commitGraph) parentsOf(hash plumbing.Hash) ([]plumbing.Hash, error) {
+	if parents, ok := g.parents[hash]; ok {
+		return parents, nil
+	}
+
+	if g.shallow == nil {
+		shallow, err := g.repository.Storer.Shallow()
+		if err != nil {
+			return nil, err
+		}
+		g.shallow = make(map[plumbing.Hash]bool, len(shallow))
+		for _, shallowHash := range shallow {
+			g.shallow[shallowHash] = true
+		}
+	}
+
+	commit, err := g.repository.CommitObject(hash)
+	if err != nil {
+		return nil, err
+	}
+	parents := commit.ParentHashes
+	// Shallow commits stay listed after deepening, so only the parents that
+	// are actually missing end the history.
+	if g.shallow[hash] {
+		parents = nil
+		for _, parent := range commit.ParentHashes {
+			if g.repository.Storer.HasEncodedObject(parent) == nil {
+				parents = append(parents, parent)
+			} else {
+				g.boundary[hash] = true
+			}
+		}
+	}
+	g.parents[hash] = parents
+
+	return parents, nil
+}
+
+// findTaggedFrontier walks from head towards the root commits without walking
+// past tagged commits, and returns the tagged commits it stops at, nearest
+// first. It fails with a ShallowRepositoryError when it reaches the end of a
+// shallow clone, as the tags past it are unknown.
+func (g *commitGraph) findTaggedFrontier(ctx context.Context, head plumbing.Hash, isTagged func(plumbing.Hash) bool) ([]plumbing.Hash, error) {
+	var frontier []plumbing.Hash
+	var isTruncated bool
+	seen := map[plumbing.Hash]bool{head: true}
+	queue := []plumbing.Hash{head}
+
+	for len(queue) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		hash := queue[0]
+		queue = queue[1:]
+		if isTagged(hash) {
+			frontier = append(frontier, hash)
+			continue
+		}
+
+		parents, err := g.parentsOf(hash)
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			if !seen[parent] {
+				seen[parent] = true
+				queue = append(queue, parent)
+			}
+		}
+		if g.boundary[hash] {
+			isTruncated = true
+		}
+	}
+	if isTruncated {
+		return nil, &ShallowRepositoryError{VisibleCommits: len(seen)}
+	}
+
+	return frontier, nil
+}
+
+// ancestorsOf returns all commits reachable from the given commit, including
+// the commit itself.
+func (g *commitGraph) ancestorsOf(ctx context.Context, hash plumbing.Hash) (map[plumbing.Hash]bool, error) {
+	if ancestors, ok := g.ancestors[hash]; ok {
+		return ancestors, nil
+	}
+
+	ancestors := map[plumbing.Hash]bool{hash: true}
+	stack := []plumbing.Hash{hash}
+	for len(stack) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		parents, err := g.parentsOf(current)
+		if err != nil {
+			return nil, err
+		}
+		for _, parent := range parents {
+			if !ancestors[parent] {
+				ancestors[parent] = true
+				stack = append(stack, parent)
+			}
+		}
+	}
+	g.ancestors[hash] = ancestors
+
+	return ancestors, nil
+}
@@ -0,0 +1,78 @@
+package git
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+var ErrHookAlreadyExists = errors.New("hook already exists")
+
+// HooksDirectory returns the directory git runs hooks from, i.e. core.hooksPath
+// if set, or the hooks directory in the git directory otherwise.
+func HooksDirectory(repository *git.Repository) (string, error) {
+	storage, ok := repository.Storer.(*filesystem.Storage)
+	if !ok {
+		return "", errors.New("hooks require a repository on disk")
+	}
+	gitDirectory := storage.Filesystem().Root()
+
+	config, err := repository.Config()
+	if err != nil {
+		return "", err
+	}
+	hooksPath := config.Raw.Section("core").Option("hooksPath")
+	if hooksPath == "" {
+		return filepath.Join(gitDirectory, "hooks"), nil
+	}
+	if filepath.IsAbs(hooksPath) {
+		return hooksPath, nil
+	}
+
+	// A relative hooks path is relative to the root of the worktree, or to
+	// the git directory of a bare repository.
+	worktree, err := repository.Worktree()
+	if err == git.ErrIsBareRepository {
+		return filepath.Join(gitDirectory, hooksPath), nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(worktree.Filesystem.Root(), hooksPath), nil
+}
+
+// InstallHook writes an executable hook script and returns its path. An
+// existing hook is only replaced if it contains the marker, i.e. it was
+// installed before, or if force is set.
+func InstallHook(repository *git.Repository, name, script, marker string, force bool) (string, error) {
+	directory, err := HooksDirectory(repository)
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(directory, name)
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", err
+	}
+	if err == nil && !force && !strings.Contains(string(existing), marker) {
+		return "", ErrHookAlreadyExists
+	}
+
+	if err := os.MkdirAll(directory, 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return "", err
+	}
+	// WriteFile keeps the mode of an existing file.
+	if err := os.Chmod(path, 0755); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
@@ -0,0 +1,68 @@
+package git_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+func TestInstallHook(t *testing.T) {
+	directory := t.TempDir()
+	repository, err := gogit.PlainInit(directory, false)
+	require.NoError(t, err)
+	hookPath := filepath.Join(directory, ".git", "hooks", "commit-msg")
+
+	path, err := git.InstallHook(repository, "commit-msg", "#!/bin/sh\n# marker\n", "# marker", false)
+	require.NoError(t, err)
+	assert.Equal(t, hookPath, path)
+
+	info, err := os.Stat(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+
+	t.Run("replaces a hook it installed", func(t *testing.T) {
+		_, err := git.InstallHook(repository, "commit-msg", "#!/bin/sh\n# marker\nexit 0\n", "# marker", false)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(hookPath)
+		require.NoError(t, err)
+		assert.Equal(t, "#!/bin/sh\n# marker\nexit 0\n", string(content))
+	})
+
+	t.Run("keeps a foreign hook unless forced", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(hookPath, []byte("#!/bin/sh\nexit 1\n"), 0644))
+
+		_, err := git.InstallHook(repository, "commit-msg", "#!/bin/sh\n# marker\n", "# marker", false)
+		assert.ErrorIs(t, err, git.ErrHookAlreadyExists)
+
+		_, err = git.InstallHook(repository, "commit-msg", "#!/bin/sh\n# marker\n", "# marker", true)
+		require.NoError(t, err)
+		info, err := os.Stat(hookPath)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	})
+}
+
+func TestHooksDirectory(t *testing.T) {
+	directory := t.TempDir()
+	repository, err := gogit.PlainInit(directory, false)
+	require.NoError(t, err)
+
+	hooksDirectory, err := git.HooksDirectory(repository)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(directory, ".git", "hooks"), hooksDirectory)
+
+	config, err := repository.Config()
+	require.NoError(t, err)
+	config.Raw.Section("core").SetOption("hooksPath", ".githooks")
+	require.NoError(t, repository.SetConfig(config))
+
+	hooksDirectory, err = git.HooksDirectory(repository)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(directory, ".githooks"), hooksDirectory)
+}
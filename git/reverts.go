@@ -0,0 +1,98 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+var (
+	revertedCommitRegex = regexp.MustCompile(`(?i)\bthis reverts commit ([0-9a-f]{7,40})\b`)
+	commitRefRegex      = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+)
+
+/*
+findRevertedRefs returns the (possibly abbreviated) hashes of the commits
+reverted by a commit, as written by
+  - git revert: `This reverts commit <hash>.` in the body
+  - a `revert:` conventional commit: a `Refs: <hash>, <hash>` footer
+*/
+func findRevertedRefs(message string, parsedCommit conventionalcommits.ParsedCommit) []string {
+	var refs []string
+	for _, matches := range revertedCommitRegex.FindAllStringSubmatch(message, -1) {
+		refs = append(refs, strings.ToLower(matches[1]))
+	}
+
+	if strings.EqualFold(parsedCommit.Type, "revert") {
+		if footer, ok := parsedCommit.Footer("Refs"); ok {
+			for _, ref := range strings.Split(footer.Value, ",") {
+				ref = strings.ToLower(strings.TrimSpace(ref))
+				if commitRefRegex.MatchString(ref) {
+					refs = append(refs, ref)
+				}
+			}
+		}
+	}
+
+	return refs
+}
+
+/*
+cancelReverts excludes every revert commit together with the commits it
+reverts when they are part of the given commits, so that none of them affects
+the next version. Only commits that are still included are paired, so a revert
+that is excluded by a skip rule or a filter does not cancel anything, and
+excluded commits keep their reason. Commits are expected newest first, which
+makes a revert of a revert cancel the revert, and leaves the originally
+reverted commit in place.
+*/
+func cancelReverts(commits []AnalyzedCommit, analyzers map[plumbing.Hash]*commitAnalyzer) []RevertPair {
+	var pairs []RevertPair
+	for i := range commits {
+		if !commits[i].Included {
+			continue
+		}
+
+		var revertedRefs []string
+		for _, ref := range analyzers[commits[i].Hash].revertedRefs {
+			j, ok := findCommitIndex(commits[i+1:], ref)
+			if !ok {
+				continue
+			}
+			reverted := &commits[i+1+j]
+			if !reverted.Included {
+				continue
+			}
+
+			commits[i].Reverts = append(commits[i].Reverts, reverted.Hash)
+			reverted.RevertedBy = commits[i].Hash
+			reverted.Included = false
+			reverted.Reason = "reverted by " + shortHash(commits[i].Hash)
+			revertedRefs = append(revertedRefs, shortHash(reverted.Hash))
+			pairs = append(pairs, RevertPair{Revert: commits[i].Hash, Reverted: reverted.Hash})
+		}
+
+		if len(revertedRefs) > 0 {
+			commits[i].Included = false
+			commits[i].Reason = "reverts " + strings.Join(revertedRefs, ", ")
+		}
+	}
+
+	return pairs
+}
+
+func findCommitIndex(commits []AnalyzedCommit, ref string) (int, bool) {
+	for i, commit := range commits {
+		if strings.HasPrefix(commit.Hash.String(), ref) {
+			return i, true
+		}
+	}
+
+	return 0, false
+}
+
+func shortHash(hash plumbing.Hash) string {
+	return hash.String()[:7]
+}
@@ -0,0 +1,226 @@
+package git_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/testutil"
+)
+
+// revertCommit builds a commit message from the hashes of the commits
+// created before it, so reverts can reference earlier commits.
+type revertCommit struct {
+	message func(hashes []plumbing.Hash) string
+	tag     string
+}
+
+func literal(message string) func([]plumbing.Hash) string {
+	return func([]plumbing.Hash) string { return message }
+}
+
+func gitRevert(subject string, index int) func([]plumbing.Hash) string {
+	return func(hashes []plumbing.Hash) string {
+		return fmt.Sprintf("Revert %q\n\nThis reverts commit %s.\n", subject, hashes[index])
+	}
+}
+
+func createRevertRepository(t *testing.T, commits []revertCommit) (*gogit.Repository, []plumbing.Hash) {
+	repository, err := testutil.SetUpInMemoryRepository()
+	require.NoError(t, err)
+
+	var hashes []plumbing.Hash
+	for _, commit := range commits {
+		hash, err := testutil.AddCommit(repository, testutil.Commit{Message: commit.message(hashes), Tag: commit.tag})
+		require.NoError(t, err)
+		hashes = append(hashes, hash)
+	}
+
+	return repository, hashes
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseCancelsReverts(t *testing.T) {
+	tests := []struct {
+		name                            string
+		commits                         []revertCommit
+		expectedConventionalCommitTypes []conventionalcommits.Type
+		expectedReverts                 [][2]int
+	}{
+		{
+			name: "git revert of a feature",
+			commits: []revertCommit{
+				{message: literal("chore: initial"), tag: "v1.0.0"},
+				{message: literal("feat: add X")},
+				{message: literal("fix: a bug")},
+				{message: gitRevert("feat: add X", 1)},
+			},
+			expectedConventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			expectedReverts:                 [][2]int{{3, 1}},
+		},
+		{
+			name: "conventional revert with abbreviated refs",
+			commits: []revertCommit{
+				{message: literal("chore: initial"), tag: "v1.0.0"},
+				{message: literal("feat!: drop API")},
+				{message: func(hashes []plumbing.Hash) string {
+					return "revert: drop API\n\nRefs: " + hashes[1].String()[:7]
+				}},
+			},
+			expectedConventionalCommitTypes: []conventionalcommits.Type{},
+			expectedReverts:                 [][2]int{{2, 1}},
+		},
+		{
+			name: "revert of a revert keeps the original commit",
+			commits: []revertCommit{
+				{message: literal("chore: initial"), tag: "v1.0.0"},
+				{message: literal("feat: add X")},
+				{message: gitRevert("feat: add X", 1)},
+				{message: gitRevert(`Revert "feat: add X"`, 2)},
+			},
+			expectedConventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Feature},
+			expectedReverts:                 [][2]int{{3, 2}},
+		},
+		{
+			name: "revert of an already released commit",
+			commits: []revertCommit{
+				{message: literal("chore: initial")},
+				{message: literal("feat: add X"), tag: "v1.0.0"},
+				{message: gitRevert("feat: add X", 1)},
+			},
+			expectedConventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Chore},
+			expectedReverts:                 nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository, hashes := createRevertRepository(t, test.commits)
+
+			actual, err := git.GetConventionalCommitTypesSinceLastRelease(
+				context.Background(),
+				repository,
+				conventionalcommits.NewTypeClassifier(),
+				nil,
+				nil,
+				nil,
+				semver.MustParse("0.0.0"),
+			)
+			require.NoError(t, err)
+
+			assert.ElementsMatch(t, test.expectedConventionalCommitTypes, actual.ConventionalCommitTypes)
+
+			var expectedReverts []git.RevertPair
+			for _, pair := range test.expectedReverts {
+				expectedReverts = append(expectedReverts, git.RevertPair{Revert: hashes[pair[0]], Reverted: hashes[pair[1]]})
+			}
+			assert.Equal(t, expectedReverts, actual.Reverts)
+
+			for _, analyzedCommit := range actual.Commits {
+				switch {
+				case len(analyzedCommit.Reverts) > 0:
+					require.Len(t, analyzedCommit.Reverts, 1)
+					assert.Equal(t, "reverts "+analyzedCommit.Reverts[0].String()[:7], analyzedCommit.Reason)
+					assert.False(t, analyzedCommit.Included)
+				case !analyzedCommit.RevertedBy.IsZero():
+					assert.Equal(t, "reverted by "+analyzedCommit.RevertedBy.String()[:7], analyzedCommit.Reason)
+					assert.False(t, analyzedCommit.Included)
+				default:
+					assert.True(t, analyzedCommit.Included)
+				}
+			}
+		})
+	}
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseForTracksKeepsExcludedReverts(t *testing.T) {
+	repository, hashes := createRevertRepository(t, []revertCommit{
+		{message: literal("chore: initial"), tag: "v1.0.0"},
+		{message: literal("feat: add X")},
+		{message: literal("fix: a bug [skip release]")},
+		{message: func(hashes []plumbing.Hash) string {
+			return fmt.Sprintf("Revert \"feat: add X\" [skip release]\n\nThis reverts commit %s.\n", hashes[1])
+		}},
+		{message: func(hashes []plumbing.Hash) string {
+			return fmt.Sprintf("Revert the bug fix\n\nThis reverts commit %s.\n", hashes[2])
+		}},
+	})
+
+	results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		[]git.Track{{
+			SkipRules:      &git.SkipRules{Markers: []string{"[skip release]"}},
+			InitialVersion: semver.MustParse("0.0.0"),
+		}},
+	)
+	require.NoError(t, err)
+
+	assert.Nil(t, results[0].Reverts)
+	assert.ElementsMatch(t, []conventionalcommits.Type{conventionalcommits.Chore, conventionalcommits.Feature}, results[0].ConventionalCommitTypes)
+
+	require.Len(t, results[0].Commits, 4)
+	commitsByHash := make(map[plumbing.Hash]git.AnalyzedCommit)
+	for _, analyzedCommit := range results[0].Commits {
+		commitsByHash[analyzedCommit.Hash] = analyzedCommit
+		assert.Empty(t, analyzedCommit.Reverts)
+		assert.True(t, analyzedCommit.RevertedBy.IsZero())
+	}
+
+	assert.True(t, commitsByHash[hashes[1]].Included)
+	assert.False(t, commitsByHash[hashes[2]].Included)
+	assert.Equal(t, "skipped by rule marker [skip release]", commitsByHash[hashes[2]].Reason)
+	assert.False(t, commitsByHash[hashes[3]].Included)
+	assert.Equal(t, "skipped by rule marker [skip release]", commitsByHash[hashes[3]].Reason)
+	assert.True(t, commitsByHash[hashes[4]].Included)
+}
+
+func TestGetConventionalCommitTypesSinceLastReleaseCancelsEveryRevertedRef(t *testing.T) {
+	repository, hashes := createRevertRepository(t, []revertCommit{
+		{message: literal("chore: initial"), tag: "v1.0.0"},
+		{message: literal("feat: add X")},
+		{message: literal("fix: a bug")},
+		{message: literal("feat!: drop Y")},
+		{message: func(hashes []plumbing.Hash) string {
+			return fmt.Sprintf("revert: add X and drop Y\n\nRefs: %s, %s", hashes[1].String()[:7], hashes[3].String()[:7])
+		}},
+	})
+
+	actual, err := git.GetConventionalCommitTypesSinceLastRelease(
+		context.Background(),
+		repository,
+		conventionalcommits.NewTypeClassifier(),
+		nil,
+		nil,
+		nil,
+		semver.MustParse("0.0.0"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []conventionalcommits.Type{conventionalcommits.Fix}, actual.ConventionalCommitTypes)
+	assert.Equal(t, []git.RevertPair{
+		{Revert: hashes[4], Reverted: hashes[1]},
+		{Revert: hashes[4], Reverted: hashes[3]},
+	}, actual.Reverts)
+
+	require.Len(t, actual.Commits, 4)
+	revert := actual.Commits[0]
+	assert.Equal(t, []plumbing.Hash{hashes[1], hashes[3]}, revert.Reverts)
+	assert.Equal(t, "reverts "+hashes[1].String()[:7]+", "+hashes[3].String()[:7], revert.Reason)
+	assert.False(t, revert.Included)
+	for _, analyzedCommit := range actual.Commits[1:] {
+		if analyzedCommit.Hash == hashes[2] {
+			assert.True(t, analyzedCommit.Included)
+			continue
+		}
+		assert.Equal(t, hashes[4], analyzedCommit.RevertedBy)
+		assert.False(t, analyzedCommit.Included)
+	}
+}
@@ -0,0 +1,155 @@
+package git
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var (
+	ErrRevisionNotFound  = errors.New("revision not found")
+	ErrAmbiguousRevision = errors.New("ambiguous revision")
+)
+
+// refPrefixes are the prefixes git tries for short reference names, see
+// git help revisions.
+var refPrefixes = []string{"", "refs/", "refs/tags/", "refs/heads/", "refs/remotes/"}
+
+// ResolveCommit resolves a revision, e.g. a branch, tag or abbreviated hash,
+// optionally followed by ~ or ^ suffixes, to the commit it points to. Unlike
+// git, it fails instead of silently picking one commit when the name of the
+// revision refers to several commits, e.g. a tag and a branch of the same name.
+func ResolveCommit(repository *git.Repository, revision string) (plumbing.Hash, error) {
+	name := revision
+	if index := strings.IndexAny(revision, "~^@:"); index >= 0 {
+		name = revision[:index]
+	}
+
+	candidates, err := findRevisionCandidates(repository, name)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("cannot resolve revision %+q: %w", revision, err)
+	}
+	if len(candidates) > 1 {
+		return plumbing.ZeroHash, fmt.Errorf("cannot resolve revision %+q: %w, it is %s", revision, ErrAmbiguousRevision, strings.Join(candidates, " and "))
+	}
+
+	// go-git reports ancestors past the root commit as io.EOF.
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if errors.Is(err, plumbing.ErrReferenceNotFound) || errors.Is(err, plumbing.ErrObjectNotFound) || errors.Is(err, io.EOF) {
+		return plumbing.ZeroHash, fmt.Errorf("cannot resolve revision %+q: %w", revision, ErrRevisionNotFound)
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("cannot resolve revision %+q: %w", revision, err)
+	}
+
+	return *hash, nil
+}
+
+// findRevisionCandidates describes the distinct commits a revision name may
+// refer to, as references or as abbreviated hash.
+func findRevisionCandidates(repository *git.Repository, name string) ([]string, error) {
+	var candidates []string
+	var commits []plumbing.Hash
+	addCandidate := func(hash plumbing.Hash, description string) {
+		if !slices.Contains(commits, hash) {
+			commits = append(commits, hash)
+			candidates = append(candidates, description)
+		}
+	}
+
+	for _, prefix := range refPrefixes {
+		reference, err := repository.Reference(plumbing.ReferenceName(prefix+name), true)
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hash, err := peelToCommit(repository, reference.Hash())
+		if err != nil {
+			continue
+		}
+		addCandidate(hash, fmt.Sprintf("%s (%s)", reference.Name(), shortHash(hash)))
+	}
+
+	for _, hash := range findCommitsWithPrefix(repository, name) {
+		addCandidate(hash, "commit "+shortHash(hash))
+	}
+
+	return candidates, nil
+}
+
+func peelToCommit(repository *git.Repository, hash plumbing.Hash) (plumbing.Hash, error) {
+	if tag, err := repository.TagObject(hash); err == nil {
+		commit, err := tag.Commit()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return commit.Hash, nil
+	}
+
+	commit, err := repository.CommitObject(hash)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return commit.Hash, nil
+}
+
+// findCommitsWithPrefix returns the commits whose hash starts with an
+// abbreviated hash of at least 4 characters, as git requires.
+func findCommitsWithPrefix(repository *git.Repository, abbreviatedHash string) []plumbing.Hash {
+	if len(abbreviatedHash) < 4 || len(abbreviatedHash) > 2*len(plumbing.ZeroHash) {
+		return nil
+	}
+	prefix, err := hex.DecodeString(abbreviatedHash[:len(abbreviatedHash)&^1])
+	if err != nil {
+		return nil
+	}
+	if _, err := hex.DecodeString(abbreviatedHash + strings.Repeat("0", len(abbreviatedHash)%2)); err != nil {
+		return nil
+	}
+
+	var hashes []plumbing.Hash
+	addCommit := func(hash plumbing.Hash) {
+		if strings.HasPrefix(hash.String(), abbreviatedHash) {
+			if _, err := repository.CommitObject(hash); err == nil {
+				hashes = append(hashes, hash)
+			}
+		}
+	}
+
+	// Repositories on disk look up the prefix in their indexes.
+	type hashesWithPrefix interface {
+		HashesWithPrefix(prefix []byte) ([]plumbing.Hash, error)
+	}
+	if storage, ok := repository.Storer.(hashesWithPrefix); ok {
+		candidates, err := storage.HashesWithPrefix(prefix)
+		if err != nil {
+			return nil
+		}
+		for _, hash := range candidates {
+			addCommit(hash)
+		}
+		return hashes
+	}
+
+	iterator, err := repository.CommitObjects()
+	if err != nil {
+		return nil
+	}
+	_ = iterator.ForEach(func(commit *object.Commit) error {
+		if bytes.HasPrefix(commit.Hash[:], prefix) {
+			addCommit(commit.Hash)
+		}
+		return nil
+	})
+	return hashes
+}
@@ -0,0 +1,65 @@
+package git_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+func TestResolveCommit(t *testing.T) {
+	repository := createGraphRepository(t, []graphCommit{
+		{id: "a", message: "chore: initial", tag: "v1.0.0"},
+		{id: "b", message: "feat: a feature", parents: []string{"a"}, offset: time.Hour, tag: "next"},
+		{id: "c", message: "fix: a bug", parents: []string{"b"}, offset: 2 * time.Hour},
+	})
+	head, err := repository.Head()
+	require.NoError(t, err)
+	headCommit, err := repository.CommitObject(head.Hash())
+	require.NoError(t, err)
+	second := headCommit.ParentHashes[0]
+	secondCommit, err := repository.CommitObject(second)
+	require.NoError(t, err)
+	first := secondCommit.ParentHashes[0]
+
+	require.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("next"), head.Hash())))
+	require.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("release"), second)))
+
+	tests := []struct {
+		revision     string
+		expectedHash plumbing.Hash
+	}{
+		{revision: "HEAD", expectedHash: head.Hash()},
+		{revision: "v1.0.0", expectedHash: first},
+		{revision: "release", expectedHash: second},
+		{revision: "release~1", expectedHash: first},
+		{revision: "refs/tags/next", expectedHash: second},
+		{revision: "refs/heads/next", expectedHash: head.Hash()},
+		{revision: first.String()[:7], expectedHash: first},
+		{revision: second.String(), expectedHash: second},
+	}
+
+	for _, test := range tests {
+		t.Run(test.revision, func(t *testing.T) {
+			hash, err := git.ResolveCommit(repository, test.revision)
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedHash, hash)
+		})
+	}
+
+	_, err = git.ResolveCommit(repository, "next")
+	assert.ErrorIs(t, err, git.ErrAmbiguousRevision)
+	assert.ErrorContains(t, err, "refs/tags/next ("+second.String()[:7]+") and refs/heads/next ("+head.Hash().String()[:7]+")")
+
+	_, err = git.ResolveCommit(repository, "next~1")
+	assert.ErrorIs(t, err, git.ErrAmbiguousRevision)
+
+	_, err = git.ResolveCommit(repository, "unknown")
+	assert.ErrorIs(t, err, git.ErrRevisionNotFound)
+
+	_, err = git.ResolveCommit(repository, "release~5")
+	assert.ErrorIs(t, err, git.ErrRevisionNotFound)
+}
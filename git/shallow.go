@@ -0,0 +1,46 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// InfiniteDepth fetches the whole history, like git fetch --unshallow.
+const InfiniteDepth = 1<<31 - 1
+
+var ErrShallowRepository = errors.New("shallow repository")
+
+// ShallowRepositoryError reports that the history of a shallow clone ends
+// before the latest release could be determined.
+type ShallowRepositoryError struct {
+	VisibleCommits int
+}
+
+func (e *ShallowRepositoryError) Error() string {
+	return fmt.Sprintf("repository is a shallow clone and its history ends after %d visible commits, before the latest release: "+
+		"fetch the full history, e.g. with fetch-depth: 0 in actions/checkout", e.VisibleCommits)
+}
+
+func (e *ShallowRepositoryError) Unwrap() error {
+	return ErrShallowRepository
+}
+
+// Deepen fetches the history of the branches and all tags of the remote up to
+// the given depth, so that a shallow clone reaches further back.
+func Deepen(ctx context.Context, repository *git.Repository, remoteName string, depth int, auth transport.AuthMethod) error {
+	err := repository.FetchContext(ctx, &git.FetchOptions{
+		RemoteName: remoteName,
+		Depth:      depth,
+		Tags:       git.AllTags,
+		Auth:       auth,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return fmt.Errorf("could not deepen the history from %s: %w", remoteName, err)
+	}
+
+	return nil
+}
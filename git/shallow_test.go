@@ -0,0 +1,56 @@
+package git_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+func TestGetConventionalCommitTypesSinceLastReleaseDetectsShallowClones(t *testing.T) {
+	origin := createRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0", files: DefaultFiles},
+		{message: "feat: a feature", files: []string{"src/main.go"}},
+		{message: "fix: a bug", files: []string{"src/main.go"}},
+		{message: "docs: readme", files: []string{"README.md"}},
+		{message: "fix: another bug", files: []string{"src/main.go"}},
+	}, false)
+	originWorktree, err := origin.Worktree()
+	require.NoError(t, err)
+
+	repository, err := gogit.PlainClone(t.TempDir(), false, &gogit.CloneOptions{
+		URL:   "file://" + originWorktree.Filesystem.Root(),
+		Depth: 2,
+		Tags:  gogit.NoTags,
+	})
+	require.NoError(t, err)
+
+	analyze := func() ([]git.ConventionalCommitTypesResult, error) {
+		return git.GetConventionalCommitTypesSinceLastReleaseForTracks(context.Background(), repository, conventionalcommits.NewTypeClassifier(), []git.Track{{
+			InitialVersion: semver.MustParse("0.0.0"),
+		}})
+	}
+
+	_, err = analyze()
+	var shallowRepositoryError *git.ShallowRepositoryError
+	require.True(t, errors.As(err, &shallowRepositoryError))
+	assert.Equal(t, 2, shallowRepositoryError.VisibleCommits)
+	assert.ErrorIs(t, err, git.ErrShallowRepository)
+
+	require.NoError(t, git.Deepen(context.Background(), repository, "origin", 3, nil))
+	_, err = analyze()
+	require.True(t, errors.As(err, &shallowRepositoryError))
+	assert.Equal(t, 3, shallowRepositoryError.VisibleCommits)
+
+	require.NoError(t, git.Deepen(context.Background(), repository, "origin", git.InfiniteDepth, nil))
+	results, err := analyze()
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", results[0].LatestReleaseTag)
+	assert.Len(t, results[0].Commits, 4)
+}
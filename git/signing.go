@@ -0,0 +1,155 @@
+package git
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	sshSignatureMagicPreamble = "SSHSIG"
+	sshSignatureVersion       = 1
+	sshSignatureNamespace     = "git"
+	sshSignatureHashAlgorithm = "sha512"
+)
+
+type GPGSigner struct {
+	entity *openpgp.Entity
+}
+
+func LoadGPGSigner(path string, passphrase string) (*GPGSigner, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read GPG signing key: %w", err)
+	}
+	defer file.Close()
+
+	entities, err := openpgp.ReadArmoredKeyRing(file)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse GPG signing key: %w", err)
+	}
+	if len(entities) == 0 || entities[0].PrivateKey == nil {
+		return nil, fmt.Errorf("GPG signing key %s does not contain a private key", path)
+	}
+
+	entity := entities[0]
+	if entity.PrivateKey.Encrypted {
+		if err := entity.DecryptPrivateKeys([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("could not decrypt GPG signing key: %w", err)
+		}
+	}
+
+	return NewGPGSigner(entity), nil
+}
+
+func NewGPGSigner(entity *openpgp.Entity) *GPGSigner {
+	return &GPGSigner{entity: entity}
+}
+
+func (s *GPGSigner) Sign(message io.Reader) ([]byte, error) {
+	var signature bytes.Buffer
+	if err := openpgp.ArmoredDetachSign(&signature, s.entity, message, nil); err != nil {
+		return nil, err
+	}
+	return signature.Bytes(), nil
+}
+
+// SSHSigner produces armored signatures in the format of `ssh-keygen -Y sign`,
+// which is what git expects when gpg.format is set to ssh.
+type SSHSigner struct {
+	signer ssh.Signer
+}
+
+func LoadSSHSigner(path string, passphrase string) (*SSHSigner, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read SSH signing key: %w", err)
+	}
+
+	var signer ssh.Signer
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(data, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse SSH signing key: %w", err)
+	}
+
+	return NewSSHSigner(signer), nil
+}
+
+func NewSSHSigner(signer ssh.Signer) *SSHSigner {
+	return &SSHSigner{signer: signer}
+}
+
+func (s *SSHSigner) Sign(message io.Reader) ([]byte, error) {
+	hash := sha512.New()
+	if _, err := io.Copy(hash, message); err != nil {
+		return nil, err
+	}
+
+	signedData := ssh.Marshal(struct {
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Hash          string
+	}{
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: sshSignatureHashAlgorithm,
+		Hash:          string(hash.Sum(nil)),
+	})
+
+	signature, err := s.sign(append([]byte(sshSignatureMagicPreamble), signedData...))
+	if err != nil {
+		return nil, err
+	}
+
+	blob := append([]byte(sshSignatureMagicPreamble), ssh.Marshal(struct {
+		Version       uint32
+		PublicKey     string
+		Namespace     string
+		Reserved      string
+		HashAlgorithm string
+		Signature     string
+	}{
+		Version:       sshSignatureVersion,
+		PublicKey:     string(s.signer.PublicKey().Marshal()),
+		Namespace:     sshSignatureNamespace,
+		HashAlgorithm: sshSignatureHashAlgorithm,
+		Signature:     string(ssh.Marshal(signature)),
+	})...)
+
+	return armorSSHSignature(blob), nil
+}
+
+func (s *SSHSigner) sign(data []byte) (*ssh.Signature, error) {
+	// The SHA-1 based ssh-rsa algorithm is not accepted for signatures
+	if algorithmSigner, ok := s.signer.(ssh.AlgorithmSigner); ok && s.signer.PublicKey().Type() == ssh.KeyAlgoRSA {
+		return algorithmSigner.SignWithAlgorithm(rand.Reader, data, ssh.KeyAlgoRSASHA512)
+	}
+	return s.signer.Sign(rand.Reader, data)
+}
+
+func armorSSHSignature(blob []byte) []byte {
+	encoded := base64.StdEncoding.EncodeToString(blob)
+
+	var armored strings.Builder
+	armored.WriteString("-----BEGIN SSH SIGNATURE-----\n")
+	for len(encoded) > 70 {
+		armored.WriteString(encoded[:70] + "\n")
+		encoded = encoded[70:]
+	}
+	armored.WriteString(encoded + "\n")
+	armored.WriteString("-----END SSH SIGNATURE-----\n")
+
+	return []byte(armored.String())
+}
@@ -0,0 +1,60 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+/*
+SkipRules exclude commits from the next version, whatever their type:
+  - Authors and Committers are matched against "Name <email>"
+  - Trailers are footer tokens, e.g. Release-Skip, or a token and the
+    required value, e.g. "Release-Skip: true", both case-insensitive
+  - Markers are matched case-insensitively against the subject, e.g. [skip release]
+*/
+type SkipRules struct {
+	Authors    []*regexp.Regexp
+	Committers []*regexp.Regexp
+	Trailers   []string
+	Markers    []string
+}
+
+// findRule returns the description of the first rule that skips the commit.
+// Without rules, no commit is skipped.
+func (r *SkipRules) findRule(commit *object.Commit, parsedCommit conventionalcommits.ParsedCommit) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+
+	for _, author := range r.Authors {
+		if author.MatchString(commit.Author.String()) {
+			return "author " + author.String(), true
+		}
+	}
+
+	for _, committer := range r.Committers {
+		if committer.MatchString(commit.Committer.String()) {
+			return "committer " + committer.String(), true
+		}
+	}
+
+	for _, trailer := range r.Trailers {
+		token, value, hasValue := strings.Cut(trailer, ":")
+		footer, ok := parsedCommit.Footer(strings.TrimSpace(token))
+		if ok && (!hasValue || strings.EqualFold(strings.TrimSpace(footer.Value), strings.TrimSpace(value))) {
+			return "trailer " + trailer, true
+		}
+	}
+
+	subject := strings.ToLower(strings.SplitN(commit.Message, "\n", 2)[0])
+	for _, marker := range r.Markers {
+		if strings.Contains(subject, strings.ToLower(marker)) {
+			return "marker " + marker, true
+		}
+	}
+
+	return "", false
+}
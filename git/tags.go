@@ -13,6 +13,13 @@ import (
 
 type Tags = map[plumbing.Hash]*semver.Version
 
+type ReleaseTag struct {
+	Name    string
+	Version *semver.Version
+}
+
+type ReleaseTags = map[plumbing.Hash]ReleaseTag
+
 type tagCandidate struct {
 	originalName string
 	version      *semver.Version
@@ -23,6 +30,10 @@ func getTagSpecificity(tagName string) int {
 	if strings.HasPrefix(cleanTag, "v") {
 		cleanTag = cleanTag[1:]
 	}
+	// Pre-release and build metadata do not make a tag more specific
+	if index := strings.IndexAny(cleanTag, "-+"); index >= 0 {
+		cleanTag = cleanTag[:index]
+	}
 	return strings.Count(cleanTag, ".")
 }
 
@@ -51,33 +62,67 @@ func areCompatibleGranularities(leftVersion, rightVersion *semver.Version) bool
 	return false
 }
 
-func selectMostSpecificTag(candidates []tagCandidate) *semver.Version {
+func selectMostSpecificTag(candidates []tagCandidate) tagCandidate {
 	if len(candidates) == 1 {
-		return candidates[0].version
+		return candidates[0]
 	}
 
 	mostSpecific := candidates[0]
-	maxSpecificity := getTagSpecificity(mostSpecific.originalName)
+	maxSpecificity := getTagSpecificity(mostSpecific.version.Original())
 
 	for _, candidate := range candidates[1:] {
-		specificity := getTagSpecificity(candidate.originalName)
-		if specificity > maxSpecificity {
+		specificity := getTagSpecificity(candidate.version.Original())
+		if specificity > maxSpecificity || (specificity == maxSpecificity && isPreferredTag(candidate, mostSpecific)) {
 			mostSpecific = candidate
 			maxSpecificity = specificity
 		}
 	}
 
-	return mostSpecific.version
+	return mostSpecific
+}
+
+// isPreferredTag breaks ties between equally specific tags on the same commit,
+// e.g. when a pre-release was promoted by tagging the same commit again.
+func isPreferredTag(candidate, current tagCandidate) bool {
+	isCandidatePreRelease := candidate.version.Prerelease() != ""
+	isCurrentPreRelease := current.version.Prerelease() != ""
+	if isCandidatePreRelease != isCurrentPreRelease {
+		return !isCandidatePreRelease
+	}
+	return candidate.version.GreaterThan(current.version)
 }
 
 func GetAllSemVerTags(repository *git.Repository, tagsFilterPathRegex *regexp.Regexp, versionRegex *regexp.Regexp) (Tags, error) {
+	releaseTags, err := GetAllReleaseTags(repository, tagsFilterPathRegex, versionRegex)
+	if err != nil {
+		return Tags{}, err
+	}
+
+	var tags = make(Tags, len(releaseTags))
+	for commitHash, releaseTag := range releaseTags {
+		tags[commitHash] = releaseTag.Version
+	}
+
+	return tags, nil
+}
+
+// VersionParser parses the version of a tag, once the version regex extracted it.
+type VersionParser func(version string) (*semver.Version, error)
+
+func GetAllReleaseTags(repository *git.Repository, tagsFilterPathRegex *regexp.Regexp, versionRegex *regexp.Regexp) (ReleaseTags, error) {
+	return GetAllReleaseTagsWithParser(repository, tagsFilterPathRegex, versionRegex, semver.NewVersion)
+}
+
+// GetAllReleaseTagsWithParser is GetAllReleaseTags for versions that are not
+// semantic versions, tags the parser rejects are skipped.
+func GetAllReleaseTagsWithParser(repository *git.Repository, tagsFilterPathRegex *regexp.Regexp, versionRegex *regexp.Regexp, parseVersion VersionParser) (ReleaseTags, error) {
 	// Algorithm: When multiple tags exist on the same commit, this function distinguishes
 	// between acceptable granularity variations (e.g., v4, v4.5, v4.5.14) and conflicting
 	// versions (e.g., v4.1.0, v4.2.0). For granularity variations, it selects the most
 	// specific tag. For conflicting versions, it returns an error.
 	tagsIterator, err := repository.Tags()
 	if err != nil {
-		return Tags{}, err
+		return ReleaseTags{}, err
 	}
 
 	tagsIterator = storer.NewReferenceFilteredIter(func(ref *plumbing.Reference) bool {
@@ -114,9 +159,9 @@ func GetAllSemVerTags(repository *git.Repository, tagsFilterPathRegex *regexp.Re
 			}
 		}
 
-		version, err := semver.NewVersion(tagName)
+		version, err := parseVersion(tagName)
 		if err != nil {
-			// Skip non-semver tags
+			// Skip tags that are not versions
 			return nil
 		}
 
@@ -127,10 +172,10 @@ func GetAllSemVerTags(repository *git.Repository, tagsFilterPathRegex *regexp.Re
 		return nil
 	})
 	if err != nil {
-		return Tags{}, err
+		return ReleaseTags{}, err
 	}
 
-	var tags = make(Tags)
+	var tags = make(ReleaseTags)
 	for commitHash, candidates := range commitTags {
 		if len(candidates) > 1 {
 			firstVersion := candidates[0].version
@@ -144,11 +189,15 @@ func GetAllSemVerTags(repository *git.Repository, tagsFilterPathRegex *regexp.Re
 			}
 
 			if hasDifferentVersions {
-				return Tags{}, errors.New(fmt.Sprintf("commit %s was tagged with multiple semver versions", commitHash.String()))
+				return ReleaseTags{}, errors.New(fmt.Sprintf("commit %s was tagged with multiple semver versions", commitHash.String()))
 			}
 		}
 
-		tags[commitHash] = selectMostSpecificTag(candidates)
+		selectedTag := selectMostSpecificTag(candidates)
+		tags[commitHash] = ReleaseTag{
+			Name:    selectedTag.originalName,
+			Version: selectedTag.version,
+		}
 	}
 
 	return tags, nil
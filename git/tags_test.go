@@ -126,6 +126,20 @@ func TestGetAllSemVerTags(t *testing.T) {
 			tagsFilterRegex:  "",
 			versionRegex:     "common-(.+)",
 		},
+		{
+			tagsPerBranch:    map[string][][]string{"main": {{"v2.0.0-rc.2", "v2.0.0"}}},
+			doesExpectError:  false,
+			expectedTagNames: []string{"2.0.0"},
+			tagsFilterRegex:  "",
+			versionRegex:     "",
+		},
+		{
+			tagsPerBranch:    map[string][][]string{"main": {{"v1.1.0-rc.2", "v1.1.0-rc.1"}}},
+			doesExpectError:  false,
+			expectedTagNames: []string{"1.1.0-rc.2"},
+			tagsFilterRegex:  "",
+			versionRegex:     "",
+		},
 	}
 
 	for _, test := range tests {
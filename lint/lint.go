@@ -0,0 +1,175 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+const (
+	RuleHeader          = "header"
+	RuleType            = "type"
+	RuleScope           = "scope"
+	RuleHeaderMaxLength = "header-max-length"
+	RuleFooterRequired  = "footer-required"
+)
+
+/*
+Options configures the linter:
+  - Parser parses the messages, it defaults to Conventional Commits
+  - Classifier defines the allowed types, i.e. the types of its rules
+  - Scopes are the allowed scopes, as globs or /regexes/, any scope is allowed without
+  - MaxHeaderLength limits the number of characters of the header, 0 means no limit
+  - RequiredFooters are footer tokens every message must have, e.g. Signed-off-by
+*/
+type Options struct {
+	Parser          conventionalcommits.CommitParser
+	Classifier      *conventionalcommits.TypeClassifier
+	Scopes          []string
+	MaxHeaderLength int
+	RequiredFooters []string
+}
+
+// Finding is a problem found in a commit message. Line and Column are 1-based,
+// and 0 when the finding does not refer to a position.
+type Finding struct {
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+}
+
+func (f Finding) String() string {
+	if f.Line == 0 {
+		return fmt.Sprintf("%s (%s)", f.Message, f.Rule)
+	}
+	return fmt.Sprintf("%d:%d: %s (%s)", f.Line, f.Column, f.Message, f.Rule)
+}
+
+type Linter struct {
+	parser          conventionalcommits.CommitParser
+	classifier      *conventionalcommits.TypeClassifier
+	scopeFilter     *conventionalcommits.ScopeFilter
+	maxHeaderLength int
+	requiredFooters []string
+}
+
+func NewLinter(options Options) (*Linter, error) {
+	if options.MaxHeaderLength < 0 {
+		return nil, errors.New("max header length must not be negative")
+	}
+
+	linter := &Linter{
+		parser:          options.Parser,
+		classifier:      options.Classifier,
+		maxHeaderLength: options.MaxHeaderLength,
+		requiredFooters: options.RequiredFooters,
+	}
+	if linter.parser == nil {
+		linter.parser = conventionalcommits.NewConventionalCommitParser()
+	}
+	if linter.classifier == nil {
+		linter.classifier = conventionalcommits.NewTypeClassifier()
+	}
+	if len(options.Scopes) > 0 {
+		var err error
+		linter.scopeFilter, err = conventionalcommits.NewScopeFilter(options.Scopes, nil, true)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return linter, nil
+}
+
+// Lint returns the findings for a commit message, which is expected without
+// comments, see CleanMessage.
+func (l *Linter) Lint(message string) []Finding {
+	findings := []Finding{}
+	header := strings.TrimSuffix(strings.SplitN(message, "\n", 2)[0], "\r")
+
+	commit, err := l.parser.Parse(message)
+	var parseError *conventionalcommits.ParseError
+	if errors.As(err, &parseError) {
+		findings = append(findings, Finding{Rule: RuleHeader, Message: parseError.Message, Line: parseError.Line, Column: parseError.Column})
+	}
+
+	if commit.Type != "" {
+		// Reverts are recognized independent of the classifier.
+		allowedTypes := append(l.classifier.GetAllTypes(), "revert")
+		if !slices.Contains(allowedTypes, strings.ToLower(commit.Type)) {
+			findings = append(findings, Finding{
+				Rule:    RuleType,
+				Message: fmt.Sprintf("type %+q is not allowed, expected one of %s", commit.Type, strings.Join(allowedTypes, ", ")),
+				Line:    1,
+				Column:  1,
+			})
+		}
+	}
+
+	if l.scopeFilter != nil {
+		for _, scope := range commit.Scopes {
+			if !l.scopeFilter.Matches([]string{scope}) {
+				findings = append(findings, Finding{
+					Rule:    RuleScope,
+					Message: fmt.Sprintf("scope %+q is not allowed", scope),
+					Line:    1,
+					Column:  strings.Index(header, scope) + 1,
+				})
+			}
+		}
+	}
+
+	if headerLength := utf8.RuneCountInString(header); l.maxHeaderLength > 0 && headerLength > l.maxHeaderLength {
+		findings = append(findings, Finding{
+			Rule:    RuleHeaderMaxLength,
+			Message: fmt.Sprintf("header is %d characters long, the maximum is %d", headerLength, l.maxHeaderLength),
+			Line:    1,
+			Column:  l.maxHeaderLength + 1,
+		})
+	}
+
+	for _, token := range l.requiredFooters {
+		if _, ok := commit.Footer(token); !ok {
+			findings = append(findings, Finding{Rule: RuleFooterRequired, Message: fmt.Sprintf("footer %+q is required", token)})
+		}
+	}
+
+	return findings
+}
+
+/*
+CleanMessage prepares a message as git does before committing:
+  - lines starting with # are removed
+  - everything below the scissors line of git commit --verbose is removed
+  - surrounding blank lines are removed
+*/
+func CleanMessage(message string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "# ") && strings.HasSuffix(line, " >8 ------------------------") {
+			break
+		}
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Trim(strings.Join(lines, "\n"), "\n")
+}
+
+// IsIgnored reports whether a message is generated by git, e.g. for merges,
+// reverts and fixups, and therefore not linted.
+func IsIgnored(message string) bool {
+	for _, prefix := range []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(message, prefix) {
+			return true
+		}
+	}
+	return false
+}
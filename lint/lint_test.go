@@ -0,0 +1,118 @@
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/lint"
+)
+
+func TestLint(t *testing.T) {
+	tests := []struct {
+		name             string
+		options          lint.Options
+		message          string
+		expectedFindings []lint.Finding
+	}{
+		{
+			name:             "valid message",
+			message:          "feat(api): add endpoint\n\nRefs: #123",
+			expectedFindings: []lint.Finding{},
+		},
+		{
+			name:    "unparseable header",
+			message: "Add endpoint",
+			expectedFindings: []lint.Finding{
+				{Rule: lint.RuleHeader, Message: "expected ':' after type", Line: 1, Column: 4},
+			},
+		},
+		{
+			name:    "unknown type",
+			message: "update: dependencies",
+			expectedFindings: []lint.Finding{
+				{Rule: lint.RuleType, Message: `type "update" is not allowed, expected one of feat, fix, build, chore, ci, docs, style, refactor, perf, test, revert`, Line: 1, Column: 1},
+			},
+		},
+		{
+			name:             "custom types",
+			options:          lint.Options{Classifier: conventionalcommits.NewTypeClassifierWithCustomPrefixes(nil, []string{"fix", "deps"}, nil)},
+			message:          "deps: bump library",
+			expectedFindings: []lint.Finding{},
+		},
+		{
+			name:             "gitmoji",
+			options:          lint.Options{Parser: conventionalcommits.NewGitmojiParser()},
+			message:          ":bug: (api): a bug",
+			expectedFindings: []lint.Finding{},
+		},
+		{
+			name:    "missing gitmoji",
+			options: lint.Options{Parser: conventionalcommits.NewGitmojiParser()},
+			message: "fix: a bug",
+			expectedFindings: []lint.Finding{
+				{Rule: lint.RuleHeader, Message: "expected a gitmoji", Line: 1, Column: 1},
+			},
+		},
+		{
+			name:    "scope not allowed",
+			options: lint.Options{Scopes: []string{"api", "cli-*"}},
+			message: "fix(cli-root,web): a bug",
+			expectedFindings: []lint.Finding{
+				{Rule: lint.RuleScope, Message: `scope "web" is not allowed`, Line: 1, Column: 14},
+			},
+		},
+		{
+			name:    "header too long",
+			options: lint.Options{MaxHeaderLength: 10},
+			message: "fix: a bug in the parser",
+			expectedFindings: []lint.Finding{
+				{Rule: lint.RuleHeaderMaxLength, Message: "header is 24 characters long, the maximum is 10", Line: 1, Column: 11},
+			},
+		},
+		{
+			name:    "required footers",
+			options: lint.Options{RequiredFooters: []string{"Signed-off-by", "Refs"}},
+			message: "fix: a bug\n\nsigned-off-by: John Doe <john.doe@example.com>",
+			expectedFindings: []lint.Finding{
+				{Rule: lint.RuleFooterRequired, Message: `footer "Refs" is required`},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			linter, err := lint.NewLinter(test.options)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedFindings, linter.Lint(test.message))
+		})
+	}
+}
+
+func TestNewLinterErrors(t *testing.T) {
+	_, err := lint.NewLinter(lint.Options{Scopes: []string{"/(/"}})
+	assert.Error(t, err)
+
+	_, err = lint.NewLinter(lint.Options{MaxHeaderLength: -1})
+	assert.Error(t, err)
+}
+
+func TestFindingString(t *testing.T) {
+	assert.Equal(t, "1:4: expected ':' after type (header)", lint.Finding{Rule: lint.RuleHeader, Message: "expected ':' after type", Line: 1, Column: 4}.String())
+	assert.Equal(t, `footer "Refs" is required (footer-required)`, lint.Finding{Rule: lint.RuleFooterRequired, Message: `footer "Refs" is required`}.String())
+}
+
+func TestCleanMessage(t *testing.T) {
+	message := "\nfeat: add endpoint\n# Please enter the commit message\n\nBody\r\n# ------------------------ >8 ------------------------\ndiff --git a/main.go b/main.go\n"
+
+	assert.Equal(t, "feat: add endpoint\n\nBody", lint.CleanMessage(message))
+}
+
+func TestIsIgnored(t *testing.T) {
+	assert.True(t, lint.IsIgnored("Merge branch 'main' into feature"))
+	assert.True(t, lint.IsIgnored("Revert \"feat: add endpoint\""))
+	assert.True(t, lint.IsIgnored("fixup! feat: add endpoint"))
+	assert.False(t, lint.IsIgnored("feat: add endpoint"))
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/mattn/go-isatty"
@@ -12,10 +13,17 @@ import (
 func main() {
 	configureLogging()
 
-	err := cli.RootCommand.Execute()
-	if err != nil {
-		log.Fatal().Msg("failed to execute root command")
+	command, err := cli.RootCommand.ExecuteC()
+	if err == nil {
+		return
 	}
+
+	// Lint problems are already reported on stdout.
+	var problemsErr *cli.LintProblemsError
+	if !errors.As(err, &problemsErr) {
+		log.Error().Err(err).Msg("failed to execute root command")
+	}
+	os.Exit(cli.ExitCode(command, err))
 }
 
 func configureLogging() {
@@ -0,0 +1,126 @@
+package nextversion
+
+import (
+	"context"
+	"errors"
+	"regexp"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/util"
+)
+
+var componentNameValidation = regexp.MustCompile(`^[a-zA-Z\d\.\-_]+$`)
+
+// Component is a separately versioned part of a monorepo.
+type Component struct {
+	Name string
+	// Paths are globs of the files that belong to the component, e.g.
+	// packages/foo/**. Without paths, every commit belongs to the component.
+	Paths []string
+	// TagPrefix is prepended to the version in the component's tags, e.g.
+	// pkg/foo/v for pkg/foo/v1.2.3.
+	TagPrefix string
+}
+
+type ComponentResult struct {
+	Name string
+	Result
+}
+
+/*
+ComputeComponents computes the next version of every component in a single
+pass over the history. Each component uses its tag prefix in place of
+Options.Prefix, Options.TagsFilterRegex and Options.VersionRegex, and its
+paths in place of Options.CommitsFilterPathRegex. The results are returned in
+the order of the components.
+*/
+func ComputeComponents(ctx context.Context, repository *gogit.Repository, options Options, components []Component) ([]ComponentResult, error) {
+	compiled, err := options.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	tracks, err := compileComponents(components, compiled)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions, err := resolveRevisions(repository, options)
+	if err != nil {
+		return nil, err
+	}
+	branch, err := currentBranch(repository, options)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tracks {
+		tracks[i].Head = revisions.head
+		tracks[i].Baseline = revisions.baseline
+		tracks[i].BaselineFilter = compiled.baselineFilter(branch)
+	}
+
+	commitTypesResults, err := analyzeTracks(ctx, repository, options, compiled, tracks)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ComponentResult, len(components))
+	state, err := readWorktreeState(repository, compiled.snapshot, revisions.head)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, component := range components {
+		result, err := newResult(commitTypesResults[i], compiled, state, component.TagPrefix, branch, options.preReleaseChannel(branch))
+		if err != nil {
+			return nil, err
+		}
+		results[i] = ComponentResult{Name: component.Name, Result: result}
+	}
+
+	return results, nil
+}
+
+func compileComponents(components []Component, compiled compiledOptions) ([]git.Track, error) {
+	if len(components) == 0 {
+		return nil, &InvalidOptionError{Option: "components", Value: "", Err: errors.New("at least one component is required")}
+	}
+
+	names := make(map[string]bool, len(components))
+	tracks := make([]git.Track, len(components))
+	for i, component := range components {
+		if !componentNameValidation.MatchString(component.Name) {
+			return nil, &InvalidOptionError{Option: "component name", Value: component.Name, Err: errors.New("component name must only contain alphanumerics, dots, dashes and underscores")}
+		}
+		if names[component.Name] {
+			return nil, &InvalidOptionError{Option: "component name", Value: component.Name, Err: errors.New("component names must be unique")}
+		}
+		names[component.Name] = true
+
+		if isValid, err := util.IsValidVersionPrefix(component.TagPrefix); !isValid {
+			return nil, &InvalidOptionError{Option: "component tag prefix", Value: component.TagPrefix, Err: err}
+		}
+
+		tracks[i] = git.Track{
+			TagsFilterRegex: regexp.MustCompile(`^` + regexp.QuoteMeta(component.TagPrefix) + `\d`),
+			VersionRegex:    regexp.MustCompile(`^` + regexp.QuoteMeta(component.TagPrefix) + `(.+)$`),
+			ParseVersion:    compiled.scheme.ParseVersion,
+			InitialVersion:  compiled.initialVersion,
+			ScopeFilter:     compiled.scopeFilter,
+			SkipRules:       compiled.skipRules,
+			CommitParser:    compiled.parser,
+			HighestBaseline: compiled.highestBaseline,
+		}
+		for _, glob := range component.Paths {
+			pathRegex, err := util.GlobToPathRegex(glob)
+			if err != nil {
+				return nil, &InvalidOptionError{Option: "component path", Value: glob, Err: err}
+			}
+			tracks[i].CommitsFilterPathRegex = append(tracks[i].CommitsFilterPathRegex, pathRegex)
+		}
+	}
+
+	return tracks, nil
+}
@@ -0,0 +1,89 @@
+package nextversion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/nextversion"
+)
+
+func TestComputeComponents(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", files: []string{"README.md"}},
+		{message: "feat: add foo", files: []string{"packages/foo/main.go"}, tag: "pkg/foo/v1.0.0"},
+		{message: "feat: add bar", files: []string{"packages/bar/main.go"}, tag: "pkg/bar/v0.1.0"},
+		{message: "fix: fix foo", files: []string{"packages/foo/main.go"}},
+		{message: "feat: add baz", files: []string{"packages/baz/main.go"}},
+		{message: "docs: update readme", files: []string{"README.md"}},
+	})
+	components := []nextversion.Component{
+		{Name: "foo", Paths: []string{"packages/foo/**"}, TagPrefix: "pkg/foo/v"},
+		{Name: "bar", Paths: []string{"packages/bar"}, TagPrefix: "pkg/bar/v"},
+		{Name: "baz", Paths: []string{"packages/baz/**", "!packages/baz/*.md"}, TagPrefix: "pkg/baz/v"},
+	}
+
+	results, err := nextversion.ComputeComponents(context.Background(), repository, nextversion.Options{}, components)
+	require.NoError(t, err)
+	require.Len(t, results, 3)
+
+	assert.Equal(t, "foo", results[0].Name)
+	assert.Equal(t, "pkg/foo/v1.0.1", results[0].VersionString())
+	assert.Equal(t, "pkg/foo/v1.0.0", results[0].BaselineTag)
+	assert.True(t, results[0].HasNextVersion)
+	assert.Len(t, results[0].Commits, 4)
+
+	assert.Equal(t, "bar", results[1].Name)
+	assert.Equal(t, "pkg/bar/v0.1.0", results[1].VersionString())
+	assert.False(t, results[1].HasNextVersion)
+
+	assert.Equal(t, "baz", results[2].Name)
+	assert.Equal(t, "pkg/baz/v0.1.0", results[2].VersionString())
+	assert.Equal(t, "", results[2].BaselineTag)
+	assert.True(t, results[2].HasNextVersion)
+	require.NotNil(t, results[2].DeterminingCommit)
+	assert.Equal(t, "feat: add baz", results[2].DeterminingCommit.Subject)
+}
+
+func TestComputeComponentsWithExcludeGlobs(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", files: []string{"README.md"}},
+		{message: "feat: add foo", files: []string{"packages/foo/main.go"}, tag: "pkg/foo/v1.0.0"},
+		{message: "feat: add bar", files: []string{"packages/bar/main.go"}},
+		{message: "feat: document foo", files: []string{"packages/foo/docs/guide.md"}},
+	})
+	components := []nextversion.Component{
+		{Name: "foo", Paths: []string{"packages/foo/**", "!packages/foo/docs/**"}, TagPrefix: "pkg/foo/v"},
+	}
+
+	results, err := nextversion.ComputeComponents(context.Background(), repository, nextversion.Options{}, components)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	assert.Equal(t, "pkg/foo/v1.0.0", results[0].VersionString())
+	assert.False(t, results[0].HasNextVersion)
+	require.Len(t, results[0].Commits, 2)
+	for _, commit := range results[0].Commits {
+		assert.False(t, commit.Included, commit.Subject)
+		assert.Equal(t, "filtered out by path", commit.Reason, commit.Subject)
+	}
+}
+
+func TestComputeComponentsErrors(t *testing.T) {
+	repository := setUpRepository(t, []commit{{message: "feat: initial feature", files: []string{"main.go"}}})
+
+	for _, components := range [][]nextversion.Component{
+		nil,
+		{{Name: "", TagPrefix: "v"}},
+		{{Name: "foo bar", TagPrefix: "v"}},
+		{{Name: "foo", TagPrefix: "foo/v"}, {Name: "foo", TagPrefix: "bar/v"}},
+		{{Name: "foo", TagPrefix: "/foo/v"}},
+	} {
+		_, err := nextversion.ComputeComponents(context.Background(), repository, nextversion.Options{}, components)
+
+		var invalidOptionError *nextversion.InvalidOptionError
+		assert.True(t, errors.As(err, &invalidOptionError), "%+v", components)
+	}
+}
@@ -0,0 +1,320 @@
+package nextversion
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/versioning"
+)
+
+type Result struct {
+	Version           *semver.Version
+	PreviousVersion   *semver.Version
+	HasNextVersion    bool
+	Prefix            string
+	Branch            string
+	PreReleaseChannel string
+	// Scheme formats the versions, see FormatVersion.
+	Scheme                  versioning.Scheme
+	Snapshot                bool
+	ConventionalCommitTypes []conventionalcommits.Type
+	BaselineTag             string
+	BaselineCommit          plumbing.Hash
+	HeadCommit              plumbing.Hash
+	Commits                 []git.AnalyzedCommit
+	DeterminingCommit       *git.AnalyzedCommit
+	// Reverts lists the revert commits that cancel out a commit of the same
+	// release, neither of them counts towards the next version.
+	Reverts []git.RevertPair
+}
+
+func (r Result) VersionString() string {
+	return r.Prefix + r.FormatVersion(r.Version)
+}
+
+// FormatVersion renders a version of the result without prefix, according to
+// its versioning scheme.
+func (r Result) FormatVersion(version *semver.Version) string {
+	if r.Scheme == nil {
+		return version.String()
+	}
+	return r.Scheme.FormatVersion(version)
+}
+
+// BumpType reports which part of the previous version was increased to reach
+// the next version: major, minor, patch or none.
+func (r Result) BumpType() string {
+	switch {
+	case !r.HasNextVersion || r.PreviousVersion == nil:
+		return "none"
+	case r.Version.Major() != r.PreviousVersion.Major():
+		return "major"
+	case r.Version.Minor() != r.PreviousVersion.Minor():
+		return "minor"
+	case r.Version.Patch() != r.PreviousVersion.Patch():
+		return "patch"
+	default:
+		return "none"
+	}
+}
+
+// Compute runs the whole next version pipeline against the given repository
+// and returns the outcome instead of writing it anywhere.
+func Compute(ctx context.Context, repository *gogit.Repository, options Options) (Result, error) {
+	compiled, err := options.compile()
+	if err != nil {
+		return Result{}, err
+	}
+
+	revisions, err := resolveRevisions(repository, options)
+	if err != nil {
+		return Result{}, err
+	}
+
+	branch, err := currentBranch(repository, options)
+	if err != nil {
+		return Result{}, err
+	}
+
+	commitTypesResults, err := analyzeTracks(ctx, repository, options, compiled, []git.Track{{
+		CommitsFilterPathRegex: compiled.commitsFilterPathRegex,
+		ScopeFilter:            compiled.scopeFilter,
+		SkipRules:              compiled.skipRules,
+		CommitParser:           compiled.parser,
+		TagsFilterRegex:        compiled.tagsFilterRegex,
+		VersionRegex:           compiled.versionRegex,
+		ParseVersion:           compiled.scheme.ParseVersion,
+		InitialVersion:         compiled.initialVersion,
+		Head:                   revisions.head,
+		Baseline:               revisions.baseline,
+		HighestBaseline:        compiled.highestBaseline,
+		BaselineFilter:         compiled.baselineFilter(branch),
+	}})
+	if err != nil {
+		return Result{}, err
+	}
+	commitTypesResult := commitTypesResults[0]
+
+	state, err := readWorktreeState(repository, compiled.snapshot, revisions.head)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return newResult(commitTypesResult, compiled, state, options.Prefix, branch, options.preReleaseChannel(branch))
+}
+
+func newResult(
+	commitTypesResult git.ConventionalCommitTypesResult,
+	compiled compiledOptions,
+	state worktreeState,
+	prefix string,
+	branch string,
+	preReleaseChannel string,
+) (Result, error) {
+	if compiled.strict {
+		var nonConventionalCommits []git.AnalyzedCommit
+		for _, commit := range commitTypesResult.Commits {
+			if commit.Included && commit.NonConventional {
+				nonConventionalCommits = append(nonConventionalCommits, commit)
+			}
+		}
+		if len(nonConventionalCommits) > 0 {
+			return Result{}, &NonConventionalCommitsError{Commits: nonConventionalCommits}
+		}
+	}
+
+	var nextVersion semver.Version
+	var hasNextVersion bool
+	determiningCommit := findDeterminingCommit(commitTypesResult.Commits)
+
+	if releaseAs := commitTypesResult.ReleaseAs; releaseAs != nil {
+		if !releaseAs.GreaterThan(commitTypesResult.LatestReleaseVersion) {
+			return Result{}, fmt.Errorf("%w: %s requested by commit %s is not greater than %s",
+				ErrReleaseAsNotGreater, compiled.scheme.FormatVersion(releaseAs), commitTypesResult.ReleaseAsCommit,
+				compiled.scheme.FormatVersion(commitTypesResult.LatestReleaseVersion))
+		}
+		if releaseTag, isTagged := findReleaseTag(commitTypesResult.Tags, releaseAs); isTagged {
+			return Result{}, fmt.Errorf("%w: %s requested by commit %s is already tagged as %s",
+				ErrVersionCollision, compiled.scheme.FormatVersion(releaseAs), commitTypesResult.ReleaseAsCommit, releaseTag.Name)
+		}
+		nextVersion, hasNextVersion = *releaseAs, true
+		determiningCommit = findCommit(commitTypesResult.Commits, commitTypesResult.ReleaseAsCommit)
+	} else {
+		var err error
+		nextVersion, hasNextVersion, err = compiled.scheme.NextVersion(commitTypesResult.LatestReleaseVersion, commitTypesResult.ConventionalCommitTypes)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	// The range is checked before a colliding version is reported, and again
+	// on the final version, since skipping tagged versions may leave it.
+	if hasNextVersion {
+		if err := checkMaintenanceRange(&nextVersion, branch, compiled, determiningCommit); err != nil {
+			return Result{}, err
+		}
+	}
+	if hasNextVersion && commitTypesResult.ReleaseAs == nil {
+		var err error
+		nextVersion, err = avoidVersionCollision(nextVersion, compiled, commitTypesResult)
+		if err != nil {
+			return Result{}, err
+		}
+		if err := checkMaintenanceRange(&nextVersion, branch, compiled, determiningCommit); err != nil {
+			return Result{}, err
+		}
+	}
+
+	// Snapshots take the place of pre-releases. A Release-As version that
+	// already is a pre-release is used as is.
+	if compiled.snapshot != nil {
+		var err error
+		nextVersion, hasNextVersion, err = calculateSnapshotVersion(nextVersion, hasNextVersion, compiled.scheme, *compiled.snapshot, state, commitTypesResult)
+		if err != nil {
+			return Result{}, err
+		}
+		preReleaseChannel = ""
+	} else if hasNextVersion && preReleaseChannel != "" && nextVersion.Prerelease() == "" {
+		var err error
+		nextVersion, hasNextVersion, err = calculatePreReleaseVersion(nextVersion, preReleaseChannel, commitTypesResult)
+		if err != nil {
+			return Result{}, err
+		}
+	}
+
+	return Result{
+		Version:                 &nextVersion,
+		PreviousVersion:         commitTypesResult.LatestReleaseVersion,
+		HasNextVersion:          hasNextVersion,
+		Prefix:                  prefix,
+		Branch:                  branch,
+		PreReleaseChannel:       preReleaseChannel,
+		Scheme:                  compiled.scheme,
+		Snapshot:                compiled.snapshot != nil,
+		ConventionalCommitTypes: commitTypesResult.ConventionalCommitTypes,
+		BaselineTag:             commitTypesResult.LatestReleaseTag,
+		BaselineCommit:          commitTypesResult.LatestReleaseCommit,
+		HeadCommit:              commitTypesResult.HeadCommit,
+		Commits:                 commitTypesResult.Commits,
+		DeterminingCommit:       determiningCommit,
+		Reverts:                 commitTypesResult.Reverts,
+	}, nil
+}
+
+func currentBranch(repository *gogit.Repository, options Options) (string, error) {
+	if options.Branch != "" {
+		return options.Branch, nil
+	}
+	if options.To != "" {
+		// Only a local branch name tells the branch of another revision.
+		_, err := repository.Reference(plumbing.NewBranchReferenceName(options.To), false)
+		if err != nil {
+			return "", nil
+		}
+		return options.To, nil
+	}
+
+	head, err := repository.Head()
+	if err == plumbing.ErrReferenceNotFound {
+		return "", ErrNoCommitsFound
+	}
+	if err != nil {
+		return "", err
+	}
+	if !head.Name().IsBranch() {
+		return "", nil
+	}
+
+	return head.Name().Short(), nil
+}
+
+func calculatePreReleaseVersion(
+	nextVersion semver.Version,
+	preReleaseChannel string,
+	commitTypesResult git.ConventionalCommitTypesResult,
+) (semver.Version, bool, error) {
+	// Running again on a commit that already carries the pre-release must not
+	// produce yet another pre-release.
+	if headTag, isHeadTagged := commitTypesResult.Tags[commitTypesResult.HeadCommit]; isHeadTagged {
+		if versioning.IsPreReleaseOf(headTag.Version, nextVersion, preReleaseChannel) {
+			return *headTag.Version, false, nil
+		}
+	}
+
+	existingVersions := make([]*semver.Version, 0, len(commitTypesResult.Tags))
+	for _, releaseTag := range commitTypesResult.Tags {
+		existingVersions = append(existingVersions, releaseTag.Version)
+	}
+
+	preReleaseVersion, err := versioning.CalculatePreReleaseVersion(nextVersion, preReleaseChannel, existingVersions)
+	if err != nil {
+		return semver.Version{}, false, err
+	}
+
+	return preReleaseVersion, true, nil
+}
+
+// avoidVersionCollision makes sure that the next version is not tagged yet,
+// which happens when the baseline is not the highest release, e.g. with a
+// hotfix tag placed after a newer release. It either fails or bumps the
+// version again until it is free.
+func avoidVersionCollision(nextVersion semver.Version, compiled compiledOptions, commitTypesResult git.ConventionalCommitTypesResult) (semver.Version, error) {
+	for {
+		releaseTag, isTagged := findReleaseTag(commitTypesResult.Tags, &nextVersion)
+		if !isTagged {
+			return nextVersion, nil
+		}
+		if !compiled.skipCollisions {
+			return semver.Version{}, fmt.Errorf("%w: %s is already tagged as %s, the latest release is %s",
+				ErrVersionCollision, compiled.scheme.FormatVersion(&nextVersion), releaseTag.Name,
+				compiled.scheme.FormatVersion(commitTypesResult.LatestReleaseVersion))
+		}
+
+		var err error
+		nextVersion, _, err = compiled.scheme.NextVersion(releaseTag.Version, commitTypesResult.ConventionalCommitTypes)
+		if err != nil {
+			return semver.Version{}, err
+		}
+	}
+}
+
+func findReleaseTag(tags git.ReleaseTags, version *semver.Version) (git.ReleaseTag, bool) {
+	for _, releaseTag := range tags {
+		if releaseTag.Version.Equal(version) {
+			return releaseTag, true
+		}
+	}
+
+	return git.ReleaseTag{}, false
+}
+
+func findCommit(commits []git.AnalyzedCommit, hash plumbing.Hash) *git.AnalyzedCommit {
+	for i := range commits {
+		if commits[i].Hash == hash {
+			return &commits[i]
+		}
+	}
+
+	return nil
+}
+
+// findDeterminingCommit returns the most recent included commit with the
+// highest change type, or nil when no commit results in a new version.
+func findDeterminingCommit(commits []git.AnalyzedCommit) *git.AnalyzedCommit {
+	var determiningCommit *git.AnalyzedCommit
+	for i := range commits {
+		if !commits[i].Included || commits[i].Classification.Type == conventionalcommits.Chore {
+			continue
+		}
+		if determiningCommit == nil || commits[i].Classification.Type > determiningCommit.Classification.Type {
+			determiningCommit = &commits[i]
+		}
+	}
+
+	return determiningCommit
+}
@@ -0,0 +1,855 @@
+package nextversion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/nextversion"
+	"github.com/tvcsantos/get-next-version/testutil"
+	"github.com/tvcsantos/get-next-version/versioning"
+)
+
+type commit struct {
+	message string
+	tag     string
+	files   []string
+}
+
+func setUpRepository(t *testing.T, commitHistory []commit) *gogit.Repository {
+	repository, err := testutil.SetUpInMemoryRepository()
+	require.NoError(t, err)
+
+	for _, commit := range commitHistory {
+		addCommit(t, repository, commit)
+	}
+
+	return repository
+}
+
+func addCommit(t *testing.T, repository *gogit.Repository, commit commit) {
+	_, err := testutil.AddCommit(repository, testutil.Commit{Message: commit.message, Files: commit.files, Tag: commit.tag})
+	require.NoError(t, err)
+}
+
+func TestCompute(t *testing.T) {
+	tests := []struct {
+		name                    string
+		commitHistory           []commit
+		options                 nextversion.Options
+		expectedVersion         string
+		expectedPreviousVersion string
+		expectedHasNextVersion  bool
+		expectedBumpType        string
+	}{
+		{
+			name: "no previous release",
+			commitHistory: []commit{
+				{message: "feat: initial feature"},
+			},
+			options:                 nextversion.Options{},
+			expectedVersion:         "0.1.0",
+			expectedPreviousVersion: "0.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "minor",
+		},
+		{
+			name: "fix since last release",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.0.0"},
+				{message: "fix: a bug"},
+			},
+			options:                 nextversion.Options{Prefix: "v"},
+			expectedVersion:         "v1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+		{
+			name: "breaking change since last release",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.2.3"},
+				{message: "feat!: drop support for old clients"},
+			},
+			options:                 nextversion.Options{Prefix: "v"},
+			expectedVersion:         "v2.0.0",
+			expectedPreviousVersion: "1.2.3",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "major",
+		},
+		{
+			name: "breaking change in initial development",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v0.4.2"},
+				{message: "feat!: drop support for old clients"},
+			},
+			options:                 nextversion.Options{Prefix: "v", InitialDevelopment: true},
+			expectedVersion:         "v0.5.0",
+			expectedPreviousVersion: "0.4.2",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "minor",
+		},
+		{
+			name: "feature in initial development with minor feature bump",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v0.4.2"},
+				{message: "feat: a new feature"},
+			},
+			options:                 nextversion.Options{Prefix: "v", InitialDevelopment: true, InitialDevelopmentFeatureBump: "minor"},
+			expectedVersion:         "v0.5.0",
+			expectedPreviousVersion: "0.4.2",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "minor",
+		},
+		{
+			name: "graduate from initial development",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v0.4.2"},
+				{message: "chore: tidy up"},
+			},
+			options:                 nextversion.Options{Prefix: "v", InitialDevelopment: true, Graduate: true},
+			expectedVersion:         "v1.0.0",
+			expectedPreviousVersion: "0.4.2",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "major",
+		},
+		{
+			name: "release as footer overrides the computed bump",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v0.4.2"},
+				{message: "chore: prepare stable release\n\nRelease-As: 1.0.0"},
+				{message: "fix: a bug"},
+			},
+			options:                 nextversion.Options{Prefix: "v"},
+			expectedVersion:         "v1.0.0",
+			expectedPreviousVersion: "0.4.2",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "major",
+		},
+		{
+			name: "most recent release as footer wins",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.0.0"},
+				{message: "chore: skip a version\n\nRelease-As: 1.2.0"},
+				{message: "chore: skip another version\n\nRelease-As: 1.3.0"},
+			},
+			options:                 nextversion.Options{Prefix: "v"},
+			expectedVersion:         "v1.3.0",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "minor",
+		},
+		{
+			name: "only chores since last release",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "1.0.0"},
+				{message: "chore: tidy up"},
+			},
+			options:                 nextversion.Options{},
+			expectedVersion:         "1.0.0",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  false,
+			expectedBumpType:        "none",
+		},
+		{
+			name: "bump rules",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.0.0"},
+				{message: "deps(build): bump tool"},
+				{message: "perf: faster parsing"},
+			},
+			options: nextversion.Options{Prefix: "v", BumpRules: []nextversion.BumpRule{
+				{Type: "perf", Bump: "patch"},
+				{Type: "deps", Scope: "runtime", Bump: "patch"},
+			}},
+			expectedVersion:         "v1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+		{
+			name: "scope filters",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.0.0"},
+				{message: "feat(web): new page"},
+				{message: "feat: unscoped feature"},
+				{message: "fix(api): a bug"},
+			},
+			options:                 nextversion.Options{Prefix: "v", IncludeScopes: []string{"api*"}, UnscopedCommits: "exclude"},
+			expectedVersion:         "v1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+		{
+			name: "scope filters combined with path filters",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.0.0", files: []string{"README.md"}},
+				{message: "feat(api): docs only", files: []string{"docs/api.md"}},
+				{message: "feat(web): new page", files: []string{"src/web.go"}},
+				{message: "fix(api): a bug", files: []string{"src/api.go"}},
+			},
+			options:                 nextversion.Options{ExcludeScopes: []string{"/^w/"}, CommitsFilterPathRegex: []string{"^src/"}},
+			expectedVersion:         "1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+		{
+			name: "non-conventional commits as patches",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.0.0"},
+				{message: "Fix the login page"},
+				{message: "update: dependencies"},
+			},
+			options:                 nextversion.Options{Prefix: "v", NonConventionalAs: "patch"},
+			expectedVersion:         "v1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+		{
+			name: "skip rules",
+			commitHistory: []commit{
+				{message: "feat: initial feature", tag: "v1.0.0"},
+				{message: "feat: release tooling\n\nRelease-Skip: true"},
+				{message: "Update dependencies [skip release]"},
+				{message: "fix: a bug"},
+			},
+			options:                 nextversion.Options{Strict: true, SkipTrailers: []string{"Release-Skip: true"}, SkipMarkers: []string{"[skip release]"}, SkipAuthors: []string{`\[bot\]`}},
+			expectedVersion:         "1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+		{
+			name: "gitmoji commits",
+			commitHistory: []commit{
+				{message: ":tada: initial commit", tag: "v1.0.0"},
+				{message: "🐛 fix crash"},
+				{message: ":sparkles: (api): new endpoint"},
+				{message: ":memo: docs"},
+			},
+			options:                 nextversion.Options{CommitConvention: nextversion.CommitConventionGitmoji},
+			expectedVersion:         "1.1.0",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "minor",
+		},
+		{
+			name: "commits matching a pattern",
+			commitHistory: []commit{
+				{message: "[PROJ-1] Feat: initial", tag: "v1.0.0"},
+				{message: "[PROJ-2] Fix: crash on start"},
+				{message: "[PROJ-3] Chore: cleanup"},
+			},
+			options:                 nextversion.Options{CommitConvention: nextversion.CommitConventionRegex, CommitPattern: `^\[[A-Z]+-\d+\] (?P<type>\w+)(?P<breaking>!)?:`},
+			expectedVersion:         "1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+		{
+			name: "custom prefixes and initial version",
+			commitHistory: []commit{
+				{message: "deps: bump library"},
+			},
+			options:                 nextversion.Options{FixPrefixes: []string{"fix", "deps"}, InitialVersion: "1.0.0"},
+			expectedVersion:         "1.0.1",
+			expectedPreviousVersion: "1.0.0",
+			expectedHasNextVersion:  true,
+			expectedBumpType:        "patch",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repository := setUpRepository(t, test.commitHistory)
+
+			result, err := nextversion.Compute(context.Background(), repository, test.options)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedVersion, result.VersionString())
+			assert.Equal(t, test.expectedPreviousVersion, result.PreviousVersion.String())
+			assert.Equal(t, test.expectedHasNextVersion, result.HasNextVersion)
+			assert.Equal(t, test.expectedBumpType, result.BumpType())
+		})
+	}
+}
+
+func TestComputeErrors(t *testing.T) {
+	t.Run("returns ErrNoCommitsFound for an empty repository", func(t *testing.T) {
+		repository := setUpRepository(t, nil)
+
+		_, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+		assert.ErrorIs(t, err, nextversion.ErrNoCommitsFound)
+	})
+
+	t.Run("returns an InvalidOptionError for invalid options", func(t *testing.T) {
+		repository := setUpRepository(t, []commit{{message: "feat: initial feature"}})
+
+		for _, options := range []nextversion.Options{
+			{Prefix: "v1"},
+			{TagsFilterRegex: "("},
+			{VersionRegex: "("},
+			{CommitsFilterPathRegex: []string{"("}},
+			{InitialVersion: "not-a-version"},
+			{Prerelease: "rc_1"},
+			{InitialDevelopmentFeatureBump: "major"},
+			{InitialDevelopmentFeatureBump: "minor"},
+			{InitialDevelopmentFeatureBump: "patch"},
+			{VersionScheme: versioning.SchemeCalVer, InitialDevelopmentFeatureBump: "patch"},
+			{Branches: []nextversion.BranchRule{{Pattern: "[", Prerelease: "rc"}}},
+			{BumpRules: []nextversion.BumpRule{{Type: "perf", Bump: "huge"}}},
+			{IncludeScopes: []string{"/(/"}},
+			{ExcludeScopes: []string{"/(/"}},
+			{UnscopedCommits: "maybe"},
+			{NonConventionalAs: "major"},
+			{SkipAuthors: []string{"("}},
+			{SkipCommitters: []string{"("}},
+			{SkipTrailers: []string{": true"}},
+			{SkipMarkers: []string{" "}},
+			{Snapshot: true, SnapshotIdentifier: "dev_build"},
+			{VersionScheme: "romver"},
+			{CommitConvention: "angular"},
+			{CommitConvention: nextversion.CommitConventionRegex, CommitPattern: `^(?P<kind>\w+):`},
+			{CommitPattern: `^(?P<type>\w+):`},
+			{CalVerFormat: "YYYY.MM.MICRO"},
+			{VersionScheme: versioning.SchemeCalVer, CalVerFormat: "MM.YYYY"},
+			{VersionScheme: versioning.SchemeCalVer, Graduate: true},
+			{Snapshot: true, SnapshotMetadata: []string{"branch"}},
+			{NonConventionalAs: "huge"},
+			{BaselineStrategy: "furthest"},
+			{OnVersionCollision: "overwrite"},
+			{BumpRules: []nextversion.BumpRule{{Type: "perf", Bump: "patch"}, {Type: "perf", Bump: "minor"}}},
+			{BumpRules: []nextversion.BumpRule{{Type: "perf", Bump: "patch"}}, FixPrefixes: []string{"fix"}},
+		} {
+			_, err := nextversion.Compute(context.Background(), repository, options)
+
+			var invalidOptionError *nextversion.InvalidOptionError
+			assert.True(t, errors.As(err, &invalidOptionError))
+		}
+	})
+
+	t.Run("returns the context error when the context is cancelled", func(t *testing.T) {
+		repository := setUpRepository(t, []commit{{message: "feat: initial feature"}})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := nextversion.Compute(ctx, repository, nextversion.Options{})
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestComputeReleaseAs(t *testing.T) {
+	t.Run("reports the commit with the footer as determining commit", func(t *testing.T) {
+		repository := setUpRepository(t, []commit{
+			{message: "chore: initial", tag: "v1.0.0"},
+			{message: "fix: align with partner\n\nRelease-As: 3.0.0"},
+			{message: "feat!: breaking change"},
+		})
+
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "3.0.0", result.VersionString())
+		require.NotNil(t, result.DeterminingCommit)
+		assert.Equal(t, "fix: align with partner", result.DeterminingCommit.Subject)
+	})
+
+	t.Run("turns the version into a pre-release on a channel", func(t *testing.T) {
+		repository := setUpRepository(t, []commit{
+			{message: "chore: initial", tag: "v1.0.0"},
+			{message: "fix: a bug\n\nRelease-As: 2.0.0"},
+		})
+
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prerelease: "rc"})
+		require.NoError(t, err)
+		assert.Equal(t, "2.0.0-rc.1", result.VersionString())
+	})
+
+	t.Run("ignores footers of commits filtered out by path", func(t *testing.T) {
+		repository := setUpRepository(t, []commit{
+			{message: "chore: initial", tag: "v1.0.0", files: []string{"README.md"}},
+			{message: "fix: a bug", files: []string{"src/main.go"}},
+			{message: "docs: readme\n\nRelease-As: 2.0.0", files: []string{"README.md"}},
+		})
+
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{CommitsFilterPathRegex: []string{"^src/"}})
+		require.NoError(t, err)
+		assert.Equal(t, "1.0.1", result.VersionString())
+	})
+
+	t.Run("returns ErrReleaseAsNotGreater for a version not greater than the baseline", func(t *testing.T) {
+		for _, version := range []string{"1.2.0", "1.1.0"} {
+			repository := setUpRepository(t, []commit{
+				{message: "chore: initial", tag: "v1.2.0"},
+				{message: "fix: a bug\n\nRelease-As: " + version},
+			})
+
+			_, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+			assert.ErrorIs(t, err, nextversion.ErrReleaseAsNotGreater)
+		}
+	})
+
+	t.Run("returns ErrInvalidReleaseAs for a malformed version", func(t *testing.T) {
+		repository := setUpRepository(t, []commit{
+			{message: "chore: initial", tag: "v1.0.0"},
+			{message: "fix: a bug\n\nRelease-As: next"},
+		})
+
+		_, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+		assert.ErrorIs(t, err, nextversion.ErrInvalidReleaseAs)
+	})
+}
+
+func TestComputeCancelsReverts(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0"},
+		{message: "fix: a bug"},
+		{message: "feat: add X"},
+	})
+	head, err := repository.Head()
+	require.NoError(t, err)
+	addCommit(t, repository, commit{message: "Revert \"feat: add X\"\n\nThis reverts commit " + head.Hash().String() + "."})
+
+	result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.0.1", result.VersionString())
+	require.Len(t, result.Reverts, 1)
+	assert.Equal(t, head.Hash(), result.Reverts[0].Reverted)
+	require.NotNil(t, result.DeterminingCommit)
+	assert.Equal(t, "fix: a bug", result.DeterminingCommit.Subject)
+}
+
+func TestComputeStrict(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0"},
+		{message: "Fix the login page"},
+		{message: "fix: a bug"},
+		{message: "update: dependencies"},
+	})
+
+	_, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Strict: true})
+
+	var nonConventionalCommitsError *nextversion.NonConventionalCommitsError
+	require.ErrorAs(t, err, &nonConventionalCommitsError)
+	assert.ErrorIs(t, err, nextversion.ErrNonConventionalCommits)
+	require.Len(t, nonConventionalCommitsError.Commits, 2)
+	assert.Equal(t, "update: dependencies", nonConventionalCommitsError.Commits[0].Subject)
+	assert.Equal(t, "Fix the login page", nonConventionalCommitsError.Commits[1].Subject)
+	assert.Contains(t, err.Error(), "2 non-conventional commits since the latest release:")
+	assert.Contains(t, err.Error(), "John Doe <john.doe@example.com> Fix the login page")
+
+	addCommit(t, repository, commit{message: "chore: release", tag: "v1.0.1"})
+	addCommit(t, repository, commit{message: "feat: new feature"})
+	result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Strict: true})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.VersionString())
+}
+
+func TestComputeSnapshot(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.3.0", files: []string{"main.go"}},
+	})
+
+	t.Run("returns the release on a clean release commit", func(t *testing.T) {
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Snapshot: true})
+		require.NoError(t, err)
+		assert.Equal(t, "1.3.0", result.VersionString())
+		assert.False(t, result.HasNextVersion)
+	})
+
+	addCommit(t, repository, commit{message: "docs: readme"})
+	addCommit(t, repository, commit{message: "test: more tests"})
+
+	t.Run("snapshots the next patch version without releasable changes", func(t *testing.T) {
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v", Snapshot: true})
+		require.NoError(t, err)
+		assert.Equal(t, "v1.3.1-dev.2", result.VersionString())
+		assert.True(t, result.HasNextVersion)
+		assert.True(t, result.Snapshot)
+	})
+
+	addCommit(t, repository, commit{message: "feat: new feature"})
+
+	t.Run("snapshots the next version with build metadata", func(t *testing.T) {
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{
+			Snapshot:           true,
+			SnapshotIdentifier: "nightly",
+			SnapshotMetadata:   []string{nextversion.SnapshotMetadataHash, nextversion.SnapshotMetadataDirty},
+			Prerelease:         "rc",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.0-nightly.3+g"+result.HeadCommit.String()[:7], result.VersionString())
+		assert.Empty(t, result.PreReleaseChannel)
+	})
+
+	t.Run("marks a dirty worktree", func(t *testing.T) {
+		worktree, err := repository.Worktree()
+		require.NoError(t, err)
+		handle, err := worktree.Filesystem.Create("main.go")
+		require.NoError(t, err)
+		_, err = handle.Write([]byte("changed"))
+		require.NoError(t, err)
+		require.NoError(t, handle.Close())
+
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{
+			Snapshot:         true,
+			SnapshotMetadata: []string{nextversion.SnapshotMetadataDirty, nextversion.SnapshotMetadataDate},
+		})
+		require.NoError(t, err)
+		assert.Regexp(t, `^1\.4\.0-dev\.3\+dirty\.\d{14}$`, result.VersionString())
+	})
+}
+
+func TestComputeCalVer(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "feat: initial feature", tag: "v24.09.2"},
+		{message: "fix: a bug"},
+	})
+	options := nextversion.Options{
+		Prefix:        "v",
+		VersionScheme: versioning.SchemeCalVer,
+		CalVerFormat:  "YY.0M.MICRO",
+		Clock:         func() time.Time { return time.Date(2024, time.September, 20, 12, 0, 0, 0, time.UTC) },
+	}
+
+	result, err := nextversion.Compute(context.Background(), repository, options)
+	require.NoError(t, err)
+	assert.Equal(t, "v24.09.3", result.VersionString())
+	assert.Equal(t, "24.09.2", result.FormatVersion(result.PreviousVersion))
+	assert.True(t, result.HasNextVersion)
+
+	options.Clock = func() time.Time { return time.Date(2024, time.October, 1, 12, 0, 0, 0, time.UTC) }
+	options.Prerelease = "rc"
+	result, err = nextversion.Compute(context.Background(), repository, options)
+	require.NoError(t, err)
+	assert.Equal(t, "v24.10.0-rc.1", result.VersionString())
+}
+
+func TestComputeCalVerIgnoresSemVerTags(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.2.2"},
+		{message: "feat: last semver release", tag: "v1.2.3"},
+	})
+	head, err := repository.Head()
+	require.NoError(t, err)
+	_, err = repository.CreateTag("v2026.10.3", head.Hash(), nil)
+	require.NoError(t, err)
+	addCommit(t, repository, commit{message: "fix: a bug"})
+
+	result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{
+		Prefix:        "v",
+		VersionScheme: versioning.SchemeCalVer,
+		Clock:         func() time.Time { return time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC) },
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v2026.10.3", result.BaselineTag)
+	assert.Equal(t, "v2026.10.4", result.VersionString())
+}
+
+func TestComputeRevisions(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0"},
+		{message: "feat: a feature"},
+		{message: "fix: a bug"},
+	})
+	featureCommit, err := repository.ResolveRevision("HEAD~1")
+	require.NoError(t, err)
+	require.NoError(t, repository.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("hotfix"), *featureCommit)))
+
+	tests := []struct {
+		name             string
+		options          nextversion.Options
+		expectedVersion  string
+		expectedBranch   string
+		expectedBaseline string
+		expectedSubjects []string
+	}{
+		{
+			name:             "to a commit",
+			options:          nextversion.Options{To: "HEAD~1"},
+			expectedVersion:  "1.1.0",
+			expectedBaseline: "v1.0.0",
+			expectedSubjects: []string{"feat: a feature"},
+		},
+		{
+			name:             "to a branch",
+			options:          nextversion.Options{To: "hotfix"},
+			expectedVersion:  "1.1.0",
+			expectedBranch:   "hotfix",
+			expectedBaseline: "v1.0.0",
+			expectedSubjects: []string{"feat: a feature"},
+		},
+		{
+			name:             "from a commit",
+			options:          nextversion.Options{From: "hotfix", Branch: "main"},
+			expectedVersion:  "1.0.1",
+			expectedBranch:   "main",
+			expectedBaseline: "v1.0.0",
+			expectedSubjects: []string{"fix: a bug"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := nextversion.Compute(context.Background(), repository, test.options)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.expectedVersion, result.VersionString())
+			assert.Equal(t, test.expectedBranch, result.Branch)
+			assert.Equal(t, test.expectedBaseline, result.BaselineTag)
+			var subjects []string
+			for _, commit := range result.Commits {
+				subjects = append(subjects, commit.Subject)
+			}
+			assert.Equal(t, test.expectedSubjects, subjects)
+		})
+	}
+
+	_, err = nextversion.Compute(context.Background(), repository, nextversion.Options{To: "unknown"})
+	assert.ErrorIs(t, err, nextversion.ErrRevisionNotFound)
+
+	_, err = nextversion.Compute(context.Background(), repository, nextversion.Options{From: "unknown"})
+	assert.ErrorIs(t, err, nextversion.ErrRevisionNotFound)
+}
+
+func TestComputeVersionCollisions(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.2.4"},
+		{message: "feat: a feature", tag: "v1.3.0"},
+		{message: "fix: cherry-picked hotfix", tag: "v1.2.5"},
+		{message: "feat: another feature"},
+	})
+
+	tests := []struct {
+		name            string
+		options         nextversion.Options
+		expectedVersion string
+		expectedError   error
+	}{
+		{
+			name:          "fails on the nearest baseline",
+			options:       nextversion.Options{Prefix: "v"},
+			expectedError: nextversion.ErrVersionCollision,
+		},
+		{
+			name:            "skips to the next free version",
+			options:         nextversion.Options{Prefix: "v", OnVersionCollision: nextversion.VersionCollisionSkip},
+			expectedVersion: "v1.4.0",
+		},
+		{
+			name:            "uses the highest reachable baseline",
+			options:         nextversion.Options{Prefix: "v", BaselineStrategy: nextversion.BaselineStrategyHighestReachable},
+			expectedVersion: "v1.4.0",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := nextversion.Compute(context.Background(), repository, test.options)
+			if test.expectedError != nil {
+				assert.ErrorIs(t, err, test.expectedError)
+				assert.ErrorContains(t, err, "1.3.0 is already tagged as v1.3.0, the latest release is 1.2.5")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedVersion, result.VersionString())
+		})
+	}
+
+	addCommit(t, repository, commit{message: "feat: pinned\n\nRelease-As: 1.3.0"})
+	_, err := nextversion.Compute(context.Background(), repository, nextversion.Options{BaselineStrategy: nextversion.BaselineStrategyNearest, OnVersionCollision: nextversion.VersionCollisionSkip})
+	assert.ErrorIs(t, err, nextversion.ErrVersionCollision)
+}
+
+func TestComputeMaintenanceBranches(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.4.0"},
+		{message: "fix: a bug", tag: "v1.4.1"},
+		{message: "feat!: drop the old API", tag: "v2.0.0"},
+		{message: "fix: hotfix merged back", tag: "v1.4.2"},
+		{message: "fix: another bug"},
+	})
+	branches := []nextversion.BranchRule{
+		{Pattern: "main"},
+		{Pattern: "release/1.x", Range: "1.x"},
+		{Pattern: "release/1.4.x", Range: "~1.4", Prerelease: "rc"},
+	}
+
+	tests := []struct {
+		branch           string
+		expectedVersion  string
+		expectedBaseline string
+	}{
+		{branch: "main", expectedVersion: "v2.0.1", expectedBaseline: "v2.0.0"},
+		{branch: "release/1.x", expectedVersion: "v1.4.3", expectedBaseline: "v1.4.2"},
+		{branch: "release/1.4.x", expectedVersion: "v1.4.3-rc.1", expectedBaseline: "v1.4.2"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.branch, func(t *testing.T) {
+			result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v", Branch: test.branch, Branches: branches})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedVersion, result.VersionString())
+			assert.Equal(t, test.expectedBaseline, result.BaselineTag)
+		})
+	}
+
+	addCommit(t, repository, commit{message: "feat: a feature"})
+	_, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v", Branch: "release/1.4.x", Branches: branches})
+	assert.ErrorIs(t, err, nextversion.ErrVersionOutOfRange)
+	assert.ErrorContains(t, err, `"feat: a feature" would release 1.5.0, but branch release/1.4.x only allows versions in ~1.4`)
+
+	addCommit(t, repository, commit{message: "feat!: break the API"})
+	_, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v", Branch: "release/1.x", Branches: branches})
+	assert.ErrorIs(t, err, nextversion.ErrVersionOutOfRange)
+	assert.ErrorContains(t, err, `"feat!: break the API" would release 2.0.0, but branch release/1.x only allows versions in 1.x`)
+
+	_, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Branches: []nextversion.BranchRule{{Pattern: "main", Range: "1.x.y.z"}}})
+	var invalidOptionError *nextversion.InvalidOptionError
+	assert.True(t, errors.As(err, &invalidOptionError))
+}
+
+func TestComputeMaintenanceBranchesCheckTheFinalVersion(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.2.0"},
+		{message: "fix: a bug", tag: "v1.1.5"},
+		{message: "feat: a feature"},
+	})
+	branches := []nextversion.BranchRule{{Pattern: "release/1.x", Range: "<1.3.0"}}
+
+	_, err := nextversion.Compute(context.Background(), repository, nextversion.Options{
+		Prefix:             "v",
+		Branch:             "release/1.x",
+		Branches:           branches,
+		OnVersionCollision: nextversion.VersionCollisionSkip,
+	})
+	assert.ErrorIs(t, err, nextversion.ErrVersionOutOfRange)
+	assert.ErrorContains(t, err, `"feat: a feature" would release 1.3.0, but branch release/1.x only allows versions in <1.3.0`)
+
+	addCommit(t, repository, commit{message: "fix: pinned\n\nRelease-As: 1.4.0"})
+	_, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v", Branch: "release/1.x", Branches: branches})
+	assert.ErrorIs(t, err, nextversion.ErrVersionOutOfRange)
+	assert.ErrorContains(t, err, `"fix: pinned" would release 1.4.0, but branch release/1.x only allows versions in <1.3.0`)
+}
+
+func TestComputeDeepensShallowClones(t *testing.T) {
+	originDirectory := t.TempDir()
+	origin, err := gogit.PlainInit(originDirectory, false)
+	require.NoError(t, err)
+	for _, commit := range []commit{
+		{message: "chore: initial", tag: "v1.0.0"},
+		{message: "feat: a feature"},
+		{message: "docs: readme"},
+		{message: "fix: a bug"},
+	} {
+		addCommit(t, origin, commit)
+	}
+
+	repository, err := gogit.PlainClone(t.TempDir(), false, &gogit.CloneOptions{URL: "file://" + originDirectory, Depth: 1})
+	require.NoError(t, err)
+
+	_, err = nextversion.Compute(context.Background(), repository, nextversion.Options{})
+	assert.ErrorIs(t, err, nextversion.ErrShallowRepository)
+
+	result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{DeepenRemote: "origin"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", result.VersionString())
+	assert.Equal(t, "v1.0.0", result.BaselineTag)
+}
+
+func TestComputeReportsDeterminingCommit(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0"},
+		{message: "fix: first fix"},
+		{message: "feat: first feature"},
+		{message: "feat: second feature"},
+		{message: "chore: tidy up"},
+	})
+
+	result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.0.0", result.BaselineTag)
+	assert.Len(t, result.Commits, 4)
+	require.NotNil(t, result.DeterminingCommit)
+	assert.Equal(t, "feat: second feature", result.DeterminingCommit.Subject)
+}
+
+func TestComputePreRelease(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "v1.0.0"},
+		{message: "feat: new feature"},
+	})
+	preReleaseOptions := nextversion.Options{Prefix: "v", Prerelease: "rc"}
+
+	result, err := nextversion.Compute(context.Background(), repository, preReleaseOptions)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0-rc.1", result.VersionString())
+	assert.True(t, result.HasNextVersion)
+	assert.Equal(t, "rc", result.PreReleaseChannel)
+
+	head, err := repository.Head()
+	require.NoError(t, err)
+	_, err = repository.CreateTag("v1.1.0-rc.1", head.Hash(), nil)
+	require.NoError(t, err)
+
+	result, err = nextversion.Compute(context.Background(), repository, preReleaseOptions)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0-rc.1", result.VersionString())
+	assert.False(t, result.HasNextVersion)
+
+	addCommit(t, repository, commit{message: "fix: a bug"})
+	result, err = nextversion.Compute(context.Background(), repository, preReleaseOptions)
+	require.NoError(t, err)
+	assert.Equal(t, "v1.1.0-rc.2", result.VersionString())
+	assert.Equal(t, "1.0.0", result.PreviousVersion.String())
+
+	addCommit(t, repository, commit{message: "feat!: breaking change", tag: "v1.1.0-rc.2"})
+	addCommit(t, repository, commit{message: "fix: another bug"})
+	result, err = nextversion.Compute(context.Background(), repository, preReleaseOptions)
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.0-rc.1", result.VersionString())
+
+	result, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v"})
+	require.NoError(t, err)
+	assert.Equal(t, "v2.0.0", result.VersionString())
+	assert.True(t, result.HasNextVersion)
+}
+
+func TestComputePreReleaseFromBranchRules(t *testing.T) {
+	repository := setUpRepository(t, []commit{
+		{message: "chore: initial", tag: "1.0.0"},
+		{message: "fix: a bug"},
+	})
+	branches := []nextversion.BranchRule{
+		{Pattern: "main"},
+		{Pattern: "beta/*", Prerelease: "beta"},
+	}
+
+	result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Branch: "beta/next", Branches: branches})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1-beta.1", result.VersionString())
+
+	result, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Branch: "main", Branches: branches})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1", result.VersionString())
+
+	result, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Branch: "beta/next", Branches: branches, Prerelease: "rc"})
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1-rc.1", result.VersionString())
+
+	result, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Branches: []nextversion.BranchRule{{Pattern: "master", Prerelease: "alpha"}}})
+	require.NoError(t, err)
+	assert.Equal(t, "master", result.Branch)
+	assert.Equal(t, "1.0.1-alpha.1", result.VersionString())
+}
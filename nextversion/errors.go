@@ -0,0 +1,53 @@
+package nextversion
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+var (
+	ErrNoCommitsFound         = git.ErrNoCommitsFound
+	ErrInvalidReleaseAs       = git.ErrInvalidReleaseAs
+	ErrRevisionNotFound       = git.ErrRevisionNotFound
+	ErrAmbiguousRevision      = git.ErrAmbiguousRevision
+	ErrShallowRepository      = git.ErrShallowRepository
+	ErrReleaseAsNotGreater    = errors.New("Release-As version must be greater than the latest release")
+	ErrNonConventionalCommits = errors.New("non-conventional commits found")
+	ErrVersionCollision       = errors.New("next version already exists")
+	ErrVersionOutOfRange      = errors.New("next version is outside the range of the branch")
+)
+
+// NonConventionalCommitsError lists the commits that made strict mode fail.
+type NonConventionalCommitsError struct {
+	Commits []git.AnalyzedCommit
+}
+
+func (e *NonConventionalCommitsError) Error() string {
+	var message strings.Builder
+	fmt.Fprintf(&message, "%d non-conventional commits since the latest release:", len(e.Commits))
+	for _, commit := range e.Commits {
+		fmt.Fprintf(&message, "\n  %s %s %s", commit.Hash.String()[:7], commit.Author, commit.Subject)
+	}
+	return message.String()
+}
+
+func (e *NonConventionalCommitsError) Unwrap() error {
+	return ErrNonConventionalCommits
+}
+
+type InvalidOptionError struct {
+	Option string
+	Value  string
+	Err    error
+}
+
+func (e *InvalidOptionError) Error() string {
+	return fmt.Sprintf("invalid %s %+q: %v", e.Option, e.Value, e.Err)
+}
+
+func (e *InvalidOptionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,84 @@
+package nextversion
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/Masterminds/semver"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+// branchRange is the version range of a branch rule, if any.
+type branchRange struct {
+	pattern      string
+	versionRange string
+	constraints  *semver.Constraints
+}
+
+// contains reports whether the version, ignoring pre-release and build
+// metadata, is within the range.
+func (r branchRange) contains(version *semver.Version) bool {
+	release, _ := version.SetPrerelease("")
+	release, _ = release.SetMetadata("")
+	return r.constraints.Check(&release)
+}
+
+// maintenanceRange returns the range of the branch when the first branch rule
+// that matches it has one.
+func (compiled compiledOptions) maintenanceRange(branch string) (branchRange, bool) {
+	for _, rule := range compiled.branchRanges {
+		if matches, _ := path.Match(rule.pattern, branch); matches {
+			return rule, rule.constraints != nil
+		}
+	}
+
+	return branchRange{}, false
+}
+
+/*
+baselineFilter restricts the latest release of the branch:
+  - on a maintenance branch, to the releases within its range
+  - on any other branch, to the releases outside of every maintenance range,
+    so that e.g. main ignores the hotfixes of older release lines
+*/
+func (compiled compiledOptions) baselineFilter(branch string) func(*semver.Version) bool {
+	if maintenanceRange, isMaintenance := compiled.maintenanceRange(branch); isMaintenance {
+		return maintenanceRange.contains
+	}
+
+	var maintenanceRanges []branchRange
+	for _, rule := range compiled.branchRanges {
+		if rule.constraints != nil {
+			maintenanceRanges = append(maintenanceRanges, rule)
+		}
+	}
+	if len(maintenanceRanges) == 0 {
+		return nil
+	}
+
+	return func(version *semver.Version) bool {
+		for _, maintenanceRange := range maintenanceRanges {
+			if maintenanceRange.contains(version) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// checkMaintenanceRange rejects a next version outside of the range of a
+// maintenance branch, e.g. a breaking change on release/1.x.
+func checkMaintenanceRange(nextVersion *semver.Version, branch string, compiled compiledOptions, determiningCommit *git.AnalyzedCommit) error {
+	maintenanceRange, isMaintenance := compiled.maintenanceRange(branch)
+	if !isMaintenance || maintenanceRange.contains(nextVersion) {
+		return nil
+	}
+
+	version := compiled.scheme.FormatVersion(nextVersion)
+	if determiningCommit != nil {
+		return fmt.Errorf("%w: commit %s %+q would release %s, but branch %s only allows versions in %s",
+			ErrVersionOutOfRange, determiningCommit.Hash.String()[:7], determiningCommit.Subject, version, branch, maintenanceRange.versionRange)
+	}
+	return fmt.Errorf("%w: %s is not allowed on branch %s, which only allows versions in %s",
+		ErrVersionOutOfRange, version, branch, maintenanceRange.versionRange)
+}
@@ -0,0 +1,432 @@
+package nextversion
+
+import (
+	"errors"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/util"
+	"github.com/tvcsantos/get-next-version/versioning"
+)
+
+type Options struct {
+	Prefix string
+	// CommitConvention is CommitConventionConventional (default),
+	// CommitConventionGitmoji or CommitConventionRegex, which parses headers
+	// with CommitPattern, see conventionalcommits.RegexParser.
+	CommitConvention string
+	CommitPattern    string
+	FeaturePrefixes  []string
+	FixPrefixes      []string
+	ChorePrefixes    []string
+	// Strict fails on non-conventional commits since the latest release,
+	// NonConventionalAs (none, patch or minor) otherwise sets their bump.
+	Strict            bool
+	NonConventionalAs string
+	// BumpRules replace the commit prefixes with a table of bump levels per
+	// commit type, see conventionalcommits.BumpRule. They cannot be combined
+	// with FeaturePrefixes, FixPrefixes or ChorePrefixes.
+	BumpRules              []BumpRule
+	TagsFilterRegex        string
+	VersionRegex           string
+	CommitsFilterPathRegex []string
+	// IncludeScopes and ExcludeScopes filter commits by scope, see
+	// conventionalcommits.ScopeFilter. UnscopedCommits is either
+	// UnscopedCommitsInclude (default) or UnscopedCommitsExclude.
+	IncludeScopes   []string
+	ExcludeScopes   []string
+	UnscopedCommits string
+	// SkipAuthors and SkipCommitters are regular expressions, SkipTrailers and
+	// SkipMarkers plain text, of commits that never trigger a release, see
+	// git.SkipRules.
+	SkipAuthors    []string
+	SkipCommitters []string
+	SkipTrailers   []string
+	SkipMarkers    []string
+	InitialVersion string
+	// BaselineStrategy is BaselineStrategyNearest (default) or
+	// BaselineStrategyHighestReachable, see git.Track. A next version that
+	// is already tagged fails with ErrVersionCollision, unless
+	// OnVersionCollision is VersionCollisionSkip, which moves on to the
+	// first version that is not tagged yet.
+	BaselineStrategy   string
+	OnVersionCollision string
+	Prerelease         string
+	Branch             string
+	Branches           []BranchRule
+	// VersionScheme is versioning.SchemeSemVer (default) or
+	// versioning.SchemeCalVer, whose format is CalVerFormat, see
+	// versioning.CalVerScheme. Clock returns the date of calendar versions,
+	// it defaults to time.Now.
+	VersionScheme string
+	CalVerFormat  string
+	Clock         func() time.Time
+	// InitialDevelopment enables SemVer's 0.x rules, see versioning.Options.
+	InitialDevelopment            bool
+	InitialDevelopmentFeatureBump string
+	Graduate                      bool
+	// Snapshot computes a unique development version even without releasable
+	// changes, e.g. 1.4.0-dev.7+g3f2a9c1, instead of a release or pre-release.
+	// SnapshotIdentifier defaults to dev, SnapshotMetadata lists the build
+	// metadata, see SnapshotMetadataHash, SnapshotMetadataDate and
+	// SnapshotMetadataDirty.
+	Snapshot           bool
+	SnapshotIdentifier string
+	SnapshotMetadata   []string
+	// To is the revision to compute the next version for, it defaults to
+	// HEAD. From overrides the latest release as the start of the commits,
+	// e.g. on hotfix branches. Both are resolved with git.ResolveCommit.
+	From string
+	To   string
+	// DeepenRemote is the remote to fetch more history and tags from when a
+	// shallow clone ends before the latest release, authenticating with
+	// DeepenAuth. Without remote, shallow clones fail with a
+	// git.ShallowRepositoryError instead.
+	DeepenRemote string
+	DeepenAuth   transport.AuthMethod
+}
+
+const (
+	UnscopedCommitsInclude = "include"
+	UnscopedCommitsExclude = "exclude"
+)
+
+const (
+	BaselineStrategyNearest          = "nearest"
+	BaselineStrategyHighestReachable = "highest-reachable"
+)
+
+const (
+	VersionCollisionError = "error"
+	VersionCollisionSkip  = "skip"
+)
+
+const (
+	CommitConventionConventional = "conventional"
+	CommitConventionGitmoji      = "gitmoji"
+	CommitConventionRegex        = "regex"
+)
+
+type BumpRule struct {
+	Type     string
+	Scope    string
+	Breaking bool
+	// Bump is one of none, patch, minor or major.
+	Bump string
+}
+
+type BranchRule struct {
+	Pattern    string
+	Prerelease string
+	// Range turns matching branches into maintenance branches, whose
+	// versions must satisfy the semver constraint, e.g. 1.x for release/1.x.
+	// Other branches ignore the releases within the range as baseline.
+	Range string
+}
+
+type compiledOptions struct {
+	parser                 conventionalcommits.CommitParser
+	classifier             *conventionalcommits.TypeClassifier
+	tagsFilterRegex        *regexp.Regexp
+	versionRegex           *regexp.Regexp
+	commitsFilterPathRegex []util.PathFilterRegex
+	scopeFilter            *conventionalcommits.ScopeFilter
+	skipRules              *git.SkipRules
+	snapshot               *snapshotOptions
+	strict                 bool
+	highestBaseline        bool
+	skipCollisions         bool
+	initialVersion         *semver.Version
+	scheme                 versioning.Scheme
+	branchRanges           []branchRange
+}
+
+func (options Options) preReleaseChannel(branch string) string {
+	if options.Prerelease != "" {
+		return options.Prerelease
+	}
+
+	for _, rule := range options.Branches {
+		if matches, _ := path.Match(rule.Pattern, branch); matches {
+			return rule.Prerelease
+		}
+	}
+
+	return ""
+}
+
+// CommitParser creates the parser for the commit convention of the options.
+func (options Options) CommitParser() (conventionalcommits.CommitParser, error) {
+	if options.CommitPattern != "" && options.CommitConvention != CommitConventionRegex {
+		return nil, &InvalidOptionError{Option: "commit pattern", Value: options.CommitPattern, Err: errors.New("requires the regex commit convention")}
+	}
+
+	switch options.CommitConvention {
+	case "", CommitConventionConventional:
+		return conventionalcommits.NewConventionalCommitParser(), nil
+	case CommitConventionGitmoji:
+		return conventionalcommits.NewGitmojiParser(), nil
+	case CommitConventionRegex:
+		parser, err := conventionalcommits.NewRegexParser(options.CommitPattern)
+		if err != nil {
+			return nil, &InvalidOptionError{Option: "commit pattern", Value: options.CommitPattern, Err: err}
+		}
+		return parser, nil
+	default:
+		return nil, &InvalidOptionError{Option: "commit convention", Value: options.CommitConvention, Err: errors.New("convention must be conventional, gitmoji or regex")}
+	}
+}
+
+// TypeClassifier creates the classifier for the commit prefixes or the bump
+// rules of the options.
+func (options Options) TypeClassifier() (*conventionalcommits.TypeClassifier, error) {
+	if len(options.BumpRules) == 0 {
+		return conventionalcommits.NewTypeClassifierWithCustomPrefixes(
+			options.ChorePrefixes,
+			options.FixPrefixes,
+			options.FeaturePrefixes,
+		), nil
+	}
+
+	ruleNames := make([]string, len(options.BumpRules))
+	rules := make([]conventionalcommits.BumpRule, len(options.BumpRules))
+	for i, rule := range options.BumpRules {
+		bump, err := conventionalcommits.ParseBump(rule.Bump)
+		if err != nil {
+			return nil, &InvalidOptionError{Option: "bump", Value: rule.Bump, Err: err}
+		}
+		rules[i] = conventionalcommits.BumpRule{Type: rule.Type, Scope: rule.Scope, Breaking: rule.Breaking, Bump: bump}
+		ruleNames[i] = rules[i].String()
+	}
+
+	if len(options.FeaturePrefixes) > 0 || len(options.FixPrefixes) > 0 || len(options.ChorePrefixes) > 0 {
+		return nil, &InvalidOptionError{Option: "bump rules", Value: strings.Join(ruleNames, ", "), Err: errors.New("cannot be combined with commit prefixes")}
+	}
+
+	classifier, err := conventionalcommits.NewTypeClassifierWithRules(rules)
+	if err != nil {
+		return nil, &InvalidOptionError{Option: "bump rules", Value: strings.Join(ruleNames, ", "), Err: err}
+	}
+
+	return classifier, nil
+}
+
+func (options Options) compileScopeFilter() (*conventionalcommits.ScopeFilter, error) {
+	switch options.UnscopedCommits {
+	case "", UnscopedCommitsInclude, UnscopedCommitsExclude:
+	default:
+		return nil, &InvalidOptionError{Option: "unscoped commits policy", Value: options.UnscopedCommits, Err: errors.New("policy must be include or exclude")}
+	}
+
+	for _, patterns := range []struct {
+		option   string
+		patterns []string
+	}{
+		{option: "include scope", patterns: options.IncludeScopes},
+		{option: "exclude scope", patterns: options.ExcludeScopes},
+	} {
+		for _, pattern := range patterns.patterns {
+			if _, err := conventionalcommits.NewScopeFilter([]string{pattern}, nil, false); err != nil {
+				return nil, &InvalidOptionError{Option: patterns.option, Value: pattern, Err: err}
+			}
+		}
+	}
+
+	return conventionalcommits.NewScopeFilter(options.IncludeScopes, options.ExcludeScopes, options.UnscopedCommits != UnscopedCommitsExclude)
+}
+
+func (options Options) compileSkipRules() (*git.SkipRules, error) {
+	skipRules := &git.SkipRules{Trailers: options.SkipTrailers, Markers: options.SkipMarkers}
+
+	for _, patterns := range []struct {
+		option   string
+		patterns []string
+		regexes  *[]*regexp.Regexp
+	}{
+		{option: "skip author", patterns: options.SkipAuthors, regexes: &skipRules.Authors},
+		{option: "skip committer", patterns: options.SkipCommitters, regexes: &skipRules.Committers},
+	} {
+		for _, pattern := range patterns.patterns {
+			regex, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, &InvalidOptionError{Option: patterns.option, Value: pattern, Err: err}
+			}
+			*patterns.regexes = append(*patterns.regexes, regex)
+		}
+	}
+
+	for _, trailer := range options.SkipTrailers {
+		if token, _, _ := strings.Cut(trailer, ":"); strings.TrimSpace(token) == "" {
+			return nil, &InvalidOptionError{Option: "skip trailer", Value: trailer, Err: errors.New("trailer must be a token or token: value")}
+		}
+	}
+
+	for _, marker := range options.SkipMarkers {
+		if strings.TrimSpace(marker) == "" {
+			return nil, &InvalidOptionError{Option: "skip marker", Value: marker, Err: errors.New("marker must not be empty")}
+		}
+	}
+
+	return skipRules, nil
+}
+
+func (options Options) compileScheme(versioningOptions versioning.Options) (versioning.Scheme, error) {
+	switch options.VersionScheme {
+	case "", versioning.SchemeSemVer:
+		if options.CalVerFormat != "" {
+			return nil, &InvalidOptionError{Option: "calendar version format", Value: options.CalVerFormat, Err: errors.New("requires the calver scheme")}
+		}
+		return versioning.NewSemVerScheme(versioningOptions), nil
+	case versioning.SchemeCalVer:
+		if options.InitialDevelopment || options.Graduate {
+			return nil, &InvalidOptionError{Option: "version scheme", Value: options.VersionScheme, Err: errors.New("initial development and graduate only apply to semver")}
+		}
+		format := options.CalVerFormat
+		if format == "" {
+			format = versioning.DefaultCalVerFormat
+		}
+		scheme, err := versioning.NewCalVerScheme(format, options.Clock)
+		if err != nil {
+			return nil, &InvalidOptionError{Option: "calendar version format", Value: format, Err: err}
+		}
+		return scheme, nil
+	default:
+		return nil, &InvalidOptionError{Option: "version scheme", Value: options.VersionScheme, Err: errors.New("scheme must be semver or calver")}
+	}
+}
+
+func (options Options) compile() (compiledOptions, error) {
+	var compiled compiledOptions
+	var err error
+
+	if isValid, prefixValidationError := util.IsValidVersionPrefix(options.Prefix); !isValid {
+		return compiledOptions{}, &InvalidOptionError{Option: "version prefix", Value: options.Prefix, Err: prefixValidationError}
+	}
+
+	compiled.parser, err = options.CommitParser()
+	if err != nil {
+		return compiledOptions{}, err
+	}
+	compiled.classifier, err = options.TypeClassifier()
+	if err != nil {
+		return compiledOptions{}, err
+	}
+	if options.NonConventionalAs != "" {
+		nonConventionalType, err := conventionalcommits.ParseBump(options.NonConventionalAs)
+		if err != nil || nonConventionalType == conventionalcommits.BreakingChange {
+			return compiledOptions{}, &InvalidOptionError{Option: "non-conventional bump", Value: options.NonConventionalAs, Err: errors.New("bump must be none, patch or minor")}
+		}
+		compiled.classifier.SetNonConventionalType(nonConventionalType)
+	}
+	compiled.strict = options.Strict
+
+	if options.VersionRegex != "" {
+		compiled.versionRegex, err = regexp.Compile(options.VersionRegex)
+		if err != nil {
+			return compiledOptions{}, &InvalidOptionError{Option: "version regex", Value: options.VersionRegex, Err: err}
+		}
+	}
+	if options.TagsFilterRegex != "" {
+		compiled.tagsFilterRegex, err = regexp.Compile(options.TagsFilterRegex)
+		if err != nil {
+			return compiledOptions{}, &InvalidOptionError{Option: "tags filter regex", Value: options.TagsFilterRegex, Err: err}
+		}
+	}
+	if len(options.CommitsFilterPathRegex) > 0 {
+		compiled.commitsFilterPathRegex = make([]util.PathFilterRegex, len(options.CommitsFilterPathRegex))
+		for i, regexStr := range options.CommitsFilterPathRegex {
+			compiled.commitsFilterPathRegex[i], err = util.ToPathRegex(regexStr)
+			if err != nil {
+				return compiledOptions{}, &InvalidOptionError{Option: "commits filter path regex", Value: regexStr, Err: err}
+			}
+		}
+	}
+	if len(options.IncludeScopes) > 0 || len(options.ExcludeScopes) > 0 || options.UnscopedCommits != "" {
+		compiled.scopeFilter, err = options.compileScopeFilter()
+		if err != nil {
+			return compiledOptions{}, err
+		}
+	}
+	if len(options.SkipAuthors) > 0 || len(options.SkipCommitters) > 0 || len(options.SkipTrailers) > 0 || len(options.SkipMarkers) > 0 {
+		compiled.skipRules, err = options.compileSkipRules()
+		if err != nil {
+			return compiledOptions{}, err
+		}
+	}
+	switch options.BaselineStrategy {
+	case "", BaselineStrategyNearest:
+	case BaselineStrategyHighestReachable:
+		compiled.highestBaseline = true
+	default:
+		return compiledOptions{}, &InvalidOptionError{Option: "baseline strategy", Value: options.BaselineStrategy, Err: errors.New("strategy must be nearest or highest-reachable")}
+	}
+	switch options.OnVersionCollision {
+	case "", VersionCollisionError:
+	case VersionCollisionSkip:
+		compiled.skipCollisions = true
+	default:
+		return compiledOptions{}, &InvalidOptionError{Option: "version collision policy", Value: options.OnVersionCollision, Err: errors.New("policy must be error or skip")}
+	}
+	if options.Snapshot {
+		compiled.snapshot, err = options.compileSnapshot()
+		if err != nil {
+			return compiledOptions{}, err
+		}
+	}
+	if options.InitialVersion != "" {
+		compiled.initialVersion, err = semver.NewVersion(options.InitialVersion)
+		if err != nil {
+			return compiledOptions{}, &InvalidOptionError{Option: "initial version", Value: options.InitialVersion, Err: err}
+		}
+	} else {
+		compiled.initialVersion = semver.MustParse("0.0.0")
+	}
+
+	featureBump := versioning.Bump(options.InitialDevelopmentFeatureBump)
+	if isValid, err := versioning.IsValidInitialDevelopmentFeatureBump(featureBump); !isValid {
+		return compiledOptions{}, &InvalidOptionError{Option: "initial development feature bump", Value: options.InitialDevelopmentFeatureBump, Err: err}
+	}
+	if featureBump != "" && !options.InitialDevelopment {
+		return compiledOptions{}, &InvalidOptionError{Option: "initial development feature bump", Value: options.InitialDevelopmentFeatureBump, Err: errors.New("requires initial development")}
+	}
+	compiled.scheme, err = options.compileScheme(versioning.Options{
+		InitialDevelopment:            options.InitialDevelopment,
+		InitialDevelopmentFeatureBump: featureBump,
+		Graduate:                      options.Graduate,
+	})
+	if err != nil {
+		return compiledOptions{}, err
+	}
+
+	if options.Prerelease != "" {
+		if isValid, err := versioning.IsValidPreReleaseChannel(options.Prerelease); !isValid {
+			return compiledOptions{}, &InvalidOptionError{Option: "pre-release channel", Value: options.Prerelease, Err: err}
+		}
+	}
+	for _, rule := range options.Branches {
+		if _, err := path.Match(rule.Pattern, ""); err != nil {
+			return compiledOptions{}, &InvalidOptionError{Option: "branch pattern", Value: rule.Pattern, Err: err}
+		}
+		if rule.Prerelease != "" {
+			if isValid, err := versioning.IsValidPreReleaseChannel(rule.Prerelease); !isValid {
+				return compiledOptions{}, &InvalidOptionError{Option: "pre-release channel", Value: rule.Prerelease, Err: err}
+			}
+		}
+		branchRange := branchRange{pattern: rule.Pattern, versionRange: rule.Range}
+		if rule.Range != "" {
+			branchRange.constraints, err = semver.NewConstraint(rule.Range)
+			if err != nil {
+				return compiledOptions{}, &InvalidOptionError{Option: "branch range", Value: rule.Range, Err: err}
+			}
+		}
+		compiled.branchRanges = append(compiled.branchRanges, branchRange)
+	}
+
+	return compiled, nil
+}
@@ -0,0 +1,32 @@
+package nextversion
+
+import (
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+// revisions are the resolved Options.To and Options.From, zero when unset.
+type revisions struct {
+	head     plumbing.Hash
+	baseline plumbing.Hash
+}
+
+func resolveRevisions(repository *gogit.Repository, options Options) (revisions, error) {
+	var resolved revisions
+	var err error
+	if options.To != "" {
+		resolved.head, err = git.ResolveCommit(repository, options.To)
+		if err != nil {
+			return revisions{}, err
+		}
+	}
+	if options.From != "" {
+		resolved.baseline, err = git.ResolveCommit(repository, options.From)
+		if err != nil {
+			return revisions{}, err
+		}
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,30 @@
+package nextversion
+
+import (
+	"context"
+	"errors"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+// deepenDepths are the depths fetched one after the other until the latest
+// releases are found, the last one fetches the whole history.
+var deepenDepths = []int{100, 1000, 10000, git.InfiniteDepth}
+
+// analyzeTracks analyzes the commits since the latest release of every track,
+// deepening shallow clones from Options.DeepenRemote as needed.
+func analyzeTracks(ctx context.Context, repository *gogit.Repository, options Options, compiled compiledOptions, tracks []git.Track) ([]git.ConventionalCommitTypesResult, error) {
+	for _, depth := range deepenDepths {
+		results, err := git.GetConventionalCommitTypesSinceLastReleaseForTracks(ctx, repository, compiled.classifier, tracks)
+		if options.DeepenRemote == "" || !errors.Is(err, git.ErrShallowRepository) {
+			return results, err
+		}
+
+		if err := git.Deepen(ctx, repository, options.DeepenRemote, depth, options.DeepenAuth); err != nil {
+			return nil, err
+		}
+	}
+
+	return git.GetConventionalCommitTypesSinceLastReleaseForTracks(ctx, repository, compiled.classifier, tracks)
+}
@@ -0,0 +1,169 @@
+package nextversion
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Masterminds/semver"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/versioning"
+)
+
+// Build metadata of snapshot versions.
+const (
+	SnapshotMetadataHash  = "hash"
+	SnapshotMetadataDate  = "date"
+	SnapshotMetadataDirty = "dirty"
+)
+
+const defaultSnapshotIdentifier = "dev"
+
+type snapshotOptions struct {
+	identifier string
+	metadata   []string
+}
+
+// worktreeState is the part of the repository state that ends up in the build
+// metadata of snapshot versions. It is only read when requested.
+type worktreeState struct {
+	headTime time.Time
+	isDirty  bool
+}
+
+func (options Options) compileSnapshot() (*snapshotOptions, error) {
+	snapshot := &snapshotOptions{identifier: options.SnapshotIdentifier, metadata: options.SnapshotMetadata}
+	if snapshot.identifier == "" {
+		snapshot.identifier = defaultSnapshotIdentifier
+	}
+	if isValid, err := versioning.IsValidPreReleaseChannel(snapshot.identifier); !isValid {
+		return nil, &InvalidOptionError{Option: "snapshot identifier", Value: options.SnapshotIdentifier, Err: err}
+	}
+
+	for _, metadata := range options.SnapshotMetadata {
+		switch metadata {
+		case SnapshotMetadataHash, SnapshotMetadataDate, SnapshotMetadataDirty:
+		default:
+			return nil, &InvalidOptionError{Option: "snapshot metadata", Value: metadata, Err: errors.New("metadata must be hash, date or dirty")}
+		}
+	}
+
+	return snapshot, nil
+}
+
+// readWorktreeState reads the state for the given head commit, zero for HEAD.
+// The worktree only makes a snapshot of HEAD dirty.
+func readWorktreeState(repository *gogit.Repository, snapshot *snapshotOptions, head plumbing.Hash) (worktreeState, error) {
+	var state worktreeState
+	if snapshot == nil {
+		return state, nil
+	}
+
+	repositoryHead, err := repository.Head()
+	if err != nil {
+		return worktreeState{}, err
+	}
+	if head.IsZero() {
+		head = repositoryHead.Hash()
+	}
+
+	for _, metadata := range snapshot.metadata {
+		switch metadata {
+		case SnapshotMetadataDate:
+			headCommit, err := repository.CommitObject(head)
+			if err != nil {
+				return worktreeState{}, err
+			}
+			state.headTime = headCommit.Committer.When
+		case SnapshotMetadataDirty:
+			if head != repositoryHead.Hash() {
+				continue
+			}
+			isDirty, err := isWorktreeDirty(repository)
+			if err != nil {
+				return worktreeState{}, err
+			}
+			state.isDirty = isDirty
+		}
+	}
+
+	return state, nil
+}
+
+// isWorktreeDirty reports uncommitted changes to tracked files, like
+// git describe --dirty. Bare repositories are never dirty.
+func isWorktreeDirty(repository *gogit.Repository) (bool, error) {
+	worktree, err := repository.Worktree()
+	if errors.Is(err, gogit.ErrIsBareRepository) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	status, err := worktree.Status()
+	if err != nil {
+		return false, err
+	}
+	for _, fileStatus := range status {
+		if fileStatus.Worktree == gogit.Untracked {
+			continue
+		}
+		if fileStatus.Staging != gogit.Unmodified || fileStatus.Worktree != gogit.Unmodified {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+/*
+calculateSnapshotVersion turns the next version into a snapshot version:
+  - without releasable changes, the snapshot is for the version of a fix, e.g.
+    the next patch version
+  - the pre-release counts the commits since the baseline, see versioning.CalculateSnapshotVersion
+  - on a clean release commit, the release itself is returned
+*/
+func calculateSnapshotVersion(
+	nextVersion semver.Version,
+	hasNextVersion bool,
+	scheme versioning.Scheme,
+	snapshot snapshotOptions,
+	state worktreeState,
+	commitTypesResult git.ConventionalCommitTypesResult,
+) (semver.Version, bool, error) {
+	commitCount := len(commitTypesResult.Commits)
+	if commitCount == 0 && !state.isDirty {
+		return *commitTypesResult.LatestReleaseVersion, false, nil
+	}
+	if !hasNextVersion {
+		var err error
+		nextVersion, _, err = scheme.NextVersion(commitTypesResult.LatestReleaseVersion, []conventionalcommits.Type{conventionalcommits.Fix})
+		if err != nil {
+			return semver.Version{}, false, err
+		}
+	}
+
+	var metadata []string
+	for _, key := range snapshot.metadata {
+		switch key {
+		case SnapshotMetadataHash:
+			metadata = append(metadata, "g"+commitTypesResult.HeadCommit.String()[:7])
+		case SnapshotMetadataDate:
+			metadata = append(metadata, state.headTime.UTC().Format("20060102150405"))
+		case SnapshotMetadataDirty:
+			if state.isDirty {
+				metadata = append(metadata, SnapshotMetadataDirty)
+			}
+		}
+	}
+
+	snapshotVersion, err := versioning.CalculateSnapshotVersion(nextVersion, snapshot.identifier, commitCount, metadata)
+	if err != nil {
+		return semver.Version{}, false, err
+	}
+
+	return snapshotVersion, true, nil
+}
@@ -0,0 +1,133 @@
+package nextversion
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/tvcsantos/get-next-version/git"
+)
+
+const DefaultTagMessage = "Release {{.Tag}}"
+
+var (
+	ErrNoNextVersion    = errors.New("there is no next version to tag")
+	ErrSnapshotVersion  = errors.New("snapshot versions are not tagged")
+	ErrTagAlreadyExists = git.ErrTagAlreadyExists
+)
+
+type TagOptions struct {
+	// Annotated creates an annotated tag. Tags with a message or a signer are
+	// always annotated.
+	Annotated bool
+	// Message is a text/template rendered with TagMessageData.
+	Message string
+	// TaggerName and TaggerEmail default to the identity in git config.
+	TaggerName  string
+	TaggerEmail string
+	Signer      gogit.Signer
+	// Remote is the name of the remote to push the tag to, if any.
+	Remote string
+	Auth   transport.AuthMethod
+}
+
+type TagMessageData struct {
+	Tag             string
+	Version         string
+	PreviousVersion string
+}
+
+// CreateReleaseTag tags the head commit of the given result with the next
+// version and optionally pushes the tag.
+func CreateReleaseTag(ctx context.Context, repository *gogit.Repository, result Result, options TagOptions) (*plumbing.Reference, error) {
+	if !result.HasNextVersion {
+		return nil, ErrNoNextVersion
+	}
+	// A snapshot tag would become the baseline of later runs.
+	if result.Snapshot {
+		return nil, ErrSnapshotVersion
+	}
+
+	tagName := result.VersionString()
+	annotated := options.Annotated || options.Message != "" || options.Signer != nil
+
+	var message string
+	if annotated {
+		var err error
+		message, err = renderTagMessage(options.Message, result)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tagger, err := options.tagger()
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := git.CreateTag(repository, tagName, result.HeadCommit, git.CreateTagOptions{
+		Annotated: annotated,
+		Message:   message,
+		Tagger:    tagger,
+		Signer:    options.Signer,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if options.Remote != "" {
+		if err := git.PushTag(ctx, repository, options.Remote, tagName, options.Auth); err != nil {
+			return reference, err
+		}
+	}
+
+	return reference, nil
+}
+
+func (o TagOptions) tagger() (*object.Signature, error) {
+	if o.TaggerName == "" && o.TaggerEmail == "" {
+		return nil, nil
+	}
+	if o.TaggerName == "" || o.TaggerEmail == "" {
+		return nil, &InvalidOptionError{
+			Option: "tagger",
+			Value:  strings.TrimSpace(o.TaggerName + " <" + o.TaggerEmail + ">"),
+			Err:    errors.New("both name and email must be set"),
+		}
+	}
+
+	return &object.Signature{Name: o.TaggerName, Email: o.TaggerEmail, When: time.Now()}, nil
+}
+
+func renderTagMessage(message string, result Result) (string, error) {
+	if message == "" {
+		message = DefaultTagMessage
+	}
+
+	messageTemplate, err := template.New("tag-message").Option("missingkey=error").Parse(message)
+	if err != nil {
+		return "", &InvalidOptionError{Option: "tag message", Value: message, Err: err}
+	}
+
+	data := TagMessageData{
+		Tag:     result.VersionString(),
+		Version: result.FormatVersion(result.Version),
+	}
+	if result.PreviousVersion != nil {
+		data.PreviousVersion = result.FormatVersion(result.PreviousVersion)
+	}
+
+	var rendered strings.Builder
+	if err := messageTemplate.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("could not render tag message: %w", err)
+	}
+
+	return rendered.String(), nil
+}
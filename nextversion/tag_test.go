@@ -0,0 +1,147 @@
+package nextversion_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	gogit "github.com/go-git/go-git/v5"
+	gogitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/nextversion"
+)
+
+func TestCreateReleaseTag(t *testing.T) {
+	history := []commit{
+		{message: "chore: initial", tag: "v1.0.0"},
+		{message: "feat: new feature"},
+	}
+
+	t.Run("creates a lightweight tag on head", func(t *testing.T) {
+		repository := setUpRepository(t, history)
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v"})
+		require.NoError(t, err)
+
+		reference, err := nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, "v1.1.0", reference.Name().Short())
+		assert.Equal(t, result.HeadCommit, reference.Hash())
+
+		result, err = nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v"})
+		require.NoError(t, err)
+		assert.False(t, result.HasNextVersion)
+	})
+
+	t.Run("renders the message template of an annotated tag", func(t *testing.T) {
+		repository := setUpRepository(t, history)
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v"})
+		require.NoError(t, err)
+
+		reference, err := nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{
+			Message:     "{{.Tag}} (version {{.Version}}, previously {{.PreviousVersion}})",
+			TaggerName:  "Jane Doe",
+			TaggerEmail: "jane.doe@example.com",
+		})
+		require.NoError(t, err)
+
+		tag, err := repository.TagObject(reference.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, "v1.1.0 (version 1.1.0, previously 1.0.0)\n", tag.Message)
+		assert.Equal(t, "Jane Doe", tag.Tagger.Name)
+	})
+
+	t.Run("uses the default message for annotated tags", func(t *testing.T) {
+		repository := setUpRepository(t, history)
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+		require.NoError(t, err)
+
+		reference, err := nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{
+			Annotated:   true,
+			TaggerName:  "Jane Doe",
+			TaggerEmail: "jane.doe@example.com",
+		})
+		require.NoError(t, err)
+
+		tag, err := repository.TagObject(reference.Hash())
+		require.NoError(t, err)
+		assert.Equal(t, "Release 1.1.0\n", tag.Message)
+	})
+
+	t.Run("refuses to tag a snapshot version", func(t *testing.T) {
+		repository := setUpRepository(t, history)
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Snapshot: true})
+		require.NoError(t, err)
+		require.Equal(t, "1.1.0-dev.1", result.VersionString())
+
+		_, err = nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{})
+		assert.ErrorIs(t, err, nextversion.ErrSnapshotVersion)
+
+		tags, err := repository.Tags()
+		require.NoError(t, err)
+		count := 0
+		require.NoError(t, tags.ForEach(func(*plumbing.Reference) error {
+			count++
+			return nil
+		}))
+		assert.Equal(t, 1, count)
+	})
+
+	t.Run("refuses to tag without a next version", func(t *testing.T) {
+		repository := setUpRepository(t, []commit{{message: "chore: initial", tag: "v1.0.0"}})
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+		require.NoError(t, err)
+
+		_, err = nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{})
+		assert.ErrorIs(t, err, nextversion.ErrNoNextVersion)
+	})
+
+	t.Run("refuses to overwrite an existing tag", func(t *testing.T) {
+		repository := setUpRepository(t, history)
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v"})
+		require.NoError(t, err)
+
+		_, err = nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{})
+		require.NoError(t, err)
+		_, err = nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{})
+		assert.ErrorIs(t, err, nextversion.ErrTagAlreadyExists)
+	})
+
+	t.Run("returns an InvalidOptionError for invalid options", func(t *testing.T) {
+		repository := setUpRepository(t, history)
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{})
+		require.NoError(t, err)
+
+		for _, options := range []nextversion.TagOptions{
+			{Message: "{{.Tag"},
+			{TaggerName: "Jane Doe"},
+		} {
+			_, err := nextversion.CreateReleaseTag(context.Background(), repository, result, options)
+
+			var invalidOptionError *nextversion.InvalidOptionError
+			assert.True(t, errors.As(err, &invalidOptionError))
+		}
+	})
+
+	t.Run("pushes the tag to the remote", func(t *testing.T) {
+		remoteDirectory := t.TempDir()
+		remote, err := gogit.PlainInit(remoteDirectory, true)
+		require.NoError(t, err)
+
+		repository := setUpRepository(t, history)
+		_, err = repository.CreateRemote(&gogitconfig.RemoteConfig{Name: "origin", URLs: []string{remoteDirectory}})
+		require.NoError(t, err)
+
+		result, err := nextversion.Compute(context.Background(), repository, nextversion.Options{Prefix: "v"})
+		require.NoError(t, err)
+
+		_, err = nextversion.CreateReleaseTag(context.Background(), repository, result, nextversion.TagOptions{Remote: "origin"})
+		require.NoError(t, err)
+
+		reference, err := remote.Tag("v1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, result.HeadCommit, reference.Hash())
+	})
+}
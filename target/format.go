@@ -7,8 +7,12 @@ import (
 )
 
 func Format(nextVersion semver.Version, hasNextVersion bool, format string, prefix string) []string {
-	versionString := prefix + nextVersion.String()
+	return FormatVersionString(prefix+nextVersion.String(), hasNextVersion, format)
+}
 
+// FormatVersionString formats an already rendered version string, such as the
+// tag of a calendar version, for the given output format.
+func FormatVersionString(versionString string, hasNextVersion bool, format string) []string {
 	switch format {
 	case "github-action":
 		return []string{
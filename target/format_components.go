@@ -0,0 +1,63 @@
+package target
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+type ComponentVersion struct {
+	Name           string
+	Version        semver.Version
+	HasNextVersion bool
+	Prefix         string
+	// VersionString, when set, replaces Prefix and Version in the output. It
+	// carries versions rendered by a scheme other than semantic versioning.
+	VersionString string
+}
+
+func (component ComponentVersion) versionString() string {
+	if component.VersionString != "" {
+		return component.VersionString
+	}
+	return component.Prefix + component.Version.String()
+}
+
+func FormatComponents(components []ComponentVersion, format string) []string {
+	switch format {
+	case "github-action":
+		lines := make([]string, 0, 2*len(components)+1)
+		for _, component := range components {
+			lines = append(lines,
+				fmt.Sprintf("%s_version=%s", component.Name, component.versionString()),
+				fmt.Sprintf("%s_hasNextVersion=%v", component.Name, component.HasNextVersion),
+			)
+		}
+		return append(lines, fmt.Sprintf("components=%s", formatComponentsJSON(components)))
+	case "json":
+		return []string{
+			formatComponentsJSON(components),
+		}
+	case "version":
+		lines := make([]string, len(components))
+		for i, component := range components {
+			lines[i] = fmt.Sprintf("%s=%s", component.Name, component.versionString())
+		}
+		return lines
+	default:
+		panic("invalid format")
+	}
+}
+
+// formatComponentsJSON keeps the components in their configured order, which
+// a map would not.
+func formatComponentsJSON(components []ComponentVersion) string {
+	entries := make([]string, len(components))
+	for i, component := range components {
+		name, _ := json.Marshal(component.Name)
+		entries[i] = fmt.Sprintf(`%s: {"version": "%s", "hasNextVersion": %v}`, name, component.versionString(), component.HasNextVersion)
+	}
+	return "{" + strings.Join(entries, ", ") + "}"
+}
@@ -0,0 +1,51 @@
+package target_test
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/tvcsantos/get-next-version/target"
+)
+
+func TestFormatComponents(t *testing.T) {
+	components := []target.ComponentVersion{
+		{Name: "foo", Version: *semver.MustParse("1.2.3"), HasNextVersion: true, Prefix: "pkg/foo/v"},
+		{Name: "bar", Version: *semver.MustParse("0.1.0"), HasNextVersion: false, Prefix: ""},
+	}
+
+	output := target.FormatComponents(components, "github-action")
+	assert.Equal(t, []string{
+		"foo_version=pkg/foo/v1.2.3",
+		"foo_hasNextVersion=true",
+		"bar_version=0.1.0",
+		"bar_hasNextVersion=false",
+		`components={"foo": {"version": "pkg/foo/v1.2.3", "hasNextVersion": true}, "bar": {"version": "0.1.0", "hasNextVersion": false}}`,
+	}, output)
+
+	output = target.FormatComponents(components, "json")
+	assert.Equal(t, []string{
+		`{"foo": {"version": "pkg/foo/v1.2.3", "hasNextVersion": true}, "bar": {"version": "0.1.0", "hasNextVersion": false}}`,
+	}, output)
+
+	output = target.FormatComponents(components, "version")
+	assert.Equal(t, []string{
+		"foo=pkg/foo/v1.2.3",
+		"bar=0.1.0",
+	}, output)
+
+	assert.Panics(t, func() {
+		target.FormatComponents(components, "non-existent-format")
+	})
+}
+
+func TestFormatComponentsWithVersionString(t *testing.T) {
+	components := []target.ComponentVersion{
+		{Name: "foo", Version: *semver.MustParse("2024.1.3"), HasNextVersion: true, Prefix: "v", VersionString: "v2024.01.3"},
+	}
+
+	output := target.FormatComponents(components, "version")
+	assert.Equal(t, []string{
+		"foo=v2024.01.3",
+	}, output)
+}
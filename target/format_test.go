@@ -64,3 +64,25 @@ func TestFormat(t *testing.T) {
 		target.Format(*version, true, "non-existent-format", "")
 	})
 }
+
+func TestFormatVersionString(t *testing.T) {
+	output := target.FormatVersionString("v2024.01.3", true, "github-action")
+	assert.Equal(t, []string{
+		"version=v2024.01.3",
+		"hasNextVersion=true",
+	}, output)
+
+	output = target.FormatVersionString("v2024.01.3", false, "json")
+	assert.Equal(t, []string{
+		`{"version": "v2024.01.3", "hasNextVersion": false}`,
+	}, output)
+
+	output = target.FormatVersionString("v2024.01.3", true, "version")
+	assert.Equal(t, []string{
+		"v2024.01.3",
+	}, output)
+
+	assert.Panics(t, func() {
+		target.FormatVersionString("v2024.01.3", true, "non-existent-format")
+	})
+}
@@ -0,0 +1,101 @@
+package target
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"github.com/tvcsantos/get-next-version/nextversion"
+)
+
+/*
+TemplateData is the data model available to user-defined output templates.
+Versions are rendered without the prefix; use .Tag for the prefixed version.
+*/
+type TemplateData struct {
+	Version           string
+	Tag               string
+	PreviousVersion   string
+	Prefix            string
+	BumpType          string
+	HasNextVersion    bool
+	PreReleaseChannel string
+	Snapshot          bool
+	Branch            string
+	BaselineTag       string
+	BaselineHash      string
+	HeadHash          string
+	Commits           []TemplateCommit
+}
+
+type TemplateCommit struct {
+	Hash     string
+	Subject  string
+	Message  string
+	Type     string
+	Scope    string
+	Breaking bool
+	Included bool
+	Reason   string
+}
+
+var templateFunctions = template.FuncMap{
+	"join":      strings.Join,
+	"lower":     strings.ToLower,
+	"upper":     strings.ToUpper,
+	"trimSpace": strings.TrimSpace,
+}
+
+func NewTemplateData(result nextversion.Result) TemplateData {
+	data := TemplateData{
+		Version:           result.FormatVersion(result.Version),
+		Tag:               result.VersionString(),
+		Prefix:            result.Prefix,
+		BumpType:          result.BumpType(),
+		HasNextVersion:    result.HasNextVersion,
+		PreReleaseChannel: result.PreReleaseChannel,
+		Snapshot:          result.Snapshot,
+		Branch:            result.Branch,
+		BaselineTag:       result.BaselineTag,
+		HeadHash:          result.HeadCommit.String(),
+		Commits:           make([]TemplateCommit, len(result.Commits)),
+	}
+	if result.PreviousVersion != nil {
+		data.PreviousVersion = result.FormatVersion(result.PreviousVersion)
+	}
+	if !result.BaselineCommit.IsZero() {
+		data.BaselineHash = result.BaselineCommit.String()
+	}
+	for i, commit := range result.Commits {
+		data.Commits[i] = TemplateCommit{
+			Hash:     commit.Hash.String(),
+			Subject:  commit.Subject,
+			Message:  commit.Message,
+			Type:     commit.Classification.CommitType,
+			Scope:    commit.Classification.Scope,
+			Breaking: commit.Classification.Breaking,
+			Included: commit.Included,
+			Reason:   commit.Reason,
+		}
+	}
+
+	return data
+}
+
+func ParseTemplate(name string, text string) (*template.Template, error) {
+	parsedTemplate, err := template.New(name).Funcs(templateFunctions).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse template: %w", err)
+	}
+
+	return parsedTemplate, nil
+}
+
+func RenderTemplate(writer io.Writer, outputTemplate *template.Template, data TemplateData) error {
+	if err := outputTemplate.Execute(writer, data); err != nil {
+		return fmt.Errorf("could not render template: %w", err)
+	}
+
+	return nil
+}
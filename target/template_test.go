@@ -0,0 +1,94 @@
+package target_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/git"
+	"github.com/tvcsantos/get-next-version/nextversion"
+	"github.com/tvcsantos/get-next-version/target"
+)
+
+func TestRenderTemplate(t *testing.T) {
+	result := nextversion.Result{
+		Version:         semver.MustParse("1.3.0"),
+		PreviousVersion: semver.MustParse("1.2.3"),
+		HasNextVersion:  true,
+		Prefix:          "v",
+		BaselineTag:     "v1.2.3",
+		BaselineCommit:  plumbing.NewHash("1111111111111111111111111111111111111111"),
+		HeadCommit:      plumbing.NewHash("2222222222222222222222222222222222222222"),
+		Commits: []git.AnalyzedCommit{
+			{
+				Hash:           plumbing.NewHash("2222222222222222222222222222222222222222"),
+				Subject:        "feat(api): add endpoint",
+				Classification: conventionalcommits.Classification{Type: conventionalcommits.Feature, CommitType: "feat", Scope: "api"},
+				Included:       true,
+			},
+			{
+				Hash:     plumbing.NewHash("3333333333333333333333333333333333333333"),
+				Subject:  "fix: docs only",
+				Included: false,
+				Reason:   "filtered out by path",
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		template       string
+		expectedOutput string
+	}{
+		{
+			name:           "renders the versions",
+			template:       "VERSION={{.Version}}\nTAG={{.Tag}}\nPREVIOUS={{.PreviousVersion}}\nBUMP={{.BumpType}}\n",
+			expectedOutput: "VERSION=1.3.0\nTAG=v1.3.0\nPREVIOUS=1.2.3\nBUMP=minor\n",
+		},
+		{
+			name:           "renders the baseline and head",
+			template:       "{{.BaselineTag}} {{.BaselineHash}} {{.HeadHash}} {{.HasNextVersion}}",
+			expectedOutput: "v1.2.3 1111111111111111111111111111111111111111 2222222222222222222222222222222222222222 true",
+		},
+		{
+			name:           "renders the commits",
+			template:       "{{range .Commits}}{{if .Included}}{{.Type}}/{{.Scope}}: {{.Subject}}{{else}}skipped: {{.Reason}}{{end}};{{end}}",
+			expectedOutput: "feat/api: feat(api): add endpoint;skipped: filtered out by path;",
+		},
+		{
+			name:           "provides string functions",
+			template:       `{{upper .Prefix}}{{.Version}} {{lower "RC"}}`,
+			expectedOutput: "V1.3.0 rc",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputTemplate, err := target.ParseTemplate("test", test.template)
+			require.NoError(t, err)
+
+			var output strings.Builder
+			err = target.RenderTemplate(&output, outputTemplate, target.NewTemplateData(result))
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedOutput, output.String())
+		})
+	}
+
+	t.Run("returns an error for invalid templates", func(t *testing.T) {
+		_, err := target.ParseTemplate("test", "{{.Version")
+		assert.Error(t, err)
+	})
+
+	t.Run("returns an error for unknown fields", func(t *testing.T) {
+		outputTemplate, err := target.ParseTemplate("test", "{{.Unknown}}")
+		require.NoError(t, err)
+
+		var output strings.Builder
+		err = target.RenderTemplate(&output, outputTemplate, target.NewTemplateData(result))
+		assert.Error(t, err)
+	})
+}
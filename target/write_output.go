@@ -9,8 +9,20 @@ import (
 )
 
 func WriteOutput(nextVersion semver.Version, hasNextVersion bool, target string, prefix string) error {
-	outputLines := Format(nextVersion, hasNextVersion, target, prefix)
+	return writeLines(Format(nextVersion, hasNextVersion, target, prefix), target)
+}
+
+// WriteVersionStringOutput writes an already rendered version string to the
+// given target.
+func WriteVersionStringOutput(versionString string, hasNextVersion bool, target string) error {
+	return writeLines(FormatVersionString(versionString, hasNextVersion, target), target)
+}
+
+func WriteComponentsOutput(components []ComponentVersion, target string) error {
+	return writeLines(FormatComponents(components, target), target)
+}
 
+func writeLines(outputLines []string, target string) error {
 	var outputHandle *os.File
 	switch target {
 	case "github-action":
@@ -0,0 +1,112 @@
+package testutil
+
+import (
+	"os"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// Commit describes a commit created by AddCommit. Every file is created, or
+// appended to, with the message, and the commit gets a lightweight tag if Tag
+// is set.
+type Commit struct {
+	Message string
+	Files   []string
+	Tag     string
+}
+
+// AddCommit commits on the worktree of the repository.
+func AddCommit(repository *git.Repository, commit Commit) (plumbing.Hash, error) {
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	for _, file := range commit.Files {
+		handle, err := worktree.Filesystem.OpenFile(file, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		_, err = handle.Write([]byte(commit.Message + "\n"))
+		if closeErr := handle.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if _, err := worktree.Add(file); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	hash, err := worktree.Commit(commit.Message, CreateCommitOptions())
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if commit.Tag != "" {
+		if err := CreateTag(repository, commit.Tag, hash); err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	return hash, nil
+}
+
+// SetUpInMemoryRepositoryWithCommits creates an in-memory repository with the
+// commits, oldest first, and returns their hashes in the same order.
+func SetUpInMemoryRepositoryWithCommits(commits []Commit) (*git.Repository, []plumbing.Hash, error) {
+	repository, err := SetUpInMemoryRepository()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	hashes := make([]plumbing.Hash, len(commits))
+	for i, commit := range commits {
+		hashes[i], err = AddCommit(repository, commit)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return repository, hashes, nil
+}
+
+// CreateTag creates a lightweight tag.
+func CreateTag(repository *git.Repository, name string, hash plumbing.Hash) error {
+	_, err := repository.CreateTag(name, hash, nil)
+	return err
+}
+
+// CreateCommitObject stores a commit with the given parents and date and an
+// empty tree, without touching the worktree or any reference, e.g. to build
+// commit graphs with merges.
+func CreateCommitObject(repository *git.Repository, message string, parents []plumbing.Hash, when time.Time) (plumbing.Hash, error) {
+	emptyTree := repository.Storer.NewEncodedObject()
+	if err := (&object.Tree{}).Encode(emptyTree); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	treeHash, err := repository.Storer.SetEncodedObject(emptyTree)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	signature := *CreateCommitOptions().Author
+	signature.When = when
+	commit := &object.Commit{
+		Author:       signature,
+		Committer:    signature,
+		Message:      message,
+		TreeHash:     treeHash,
+		ParentHashes: parents,
+	}
+
+	encodedCommit := repository.Storer.NewEncodedObject()
+	if err := commit.Encode(encodedCommit); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return repository.Storer.SetEncodedObject(encodedCommit)
+}
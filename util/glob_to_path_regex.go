@@ -0,0 +1,59 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+/*
+GlobToPathRegex converts a path glob into a path filter regex
+Supported syntax:
+  - * matches any sequence of characters except a slash
+  - ** matches any sequence of characters, including slashes
+  - ? matches a single character except a slash
+  - a leading ! excludes the matching paths
+  - a glob without wildcards matches the path itself and everything below it
+*/
+func GlobToPathRegex(glob string) (PathFilterRegex, error) {
+	exclude := false
+	if strings.HasPrefix(glob, "!") {
+		glob = strings.TrimPrefix(glob, "!")
+		exclude = true
+	}
+	glob = strings.TrimPrefix(glob, "./")
+
+	var regexStr strings.Builder
+	regexStr.WriteString("^")
+	if !strings.ContainsAny(glob, "*?") {
+		regexStr.WriteString(regexp.QuoteMeta(strings.TrimSuffix(glob, "/")))
+		regexStr.WriteString("(/.*)?")
+		glob = ""
+	}
+
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case strings.HasPrefix(glob[i:], "**/"):
+			regexStr.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			regexStr.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			regexStr.WriteString("[^/]*")
+		case glob[i] == '?':
+			regexStr.WriteString("[^/]")
+		default:
+			regexStr.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	regexStr.WriteString("$")
+
+	regex, err := regexp.Compile(regexStr.String())
+	if err != nil {
+		return PathFilterRegex{}, err
+	}
+	return PathFilterRegex{
+		Regex:   regex,
+		Exclude: exclude,
+	}, nil
+}
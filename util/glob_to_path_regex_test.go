@@ -0,0 +1,68 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/util"
+)
+
+func TestGlobToPathRegex(t *testing.T) {
+	tests := []struct {
+		glob            string
+		matchingPaths   []string
+		unmatchingPaths []string
+		expectedExclude bool
+	}{
+		{
+			glob:            "packages/foo",
+			matchingPaths:   []string{"packages/foo", "packages/foo/main.go", "packages/foo/cmd/main.go"},
+			unmatchingPaths: []string{"packages/foobar/main.go", "packages/bar/main.go"},
+		},
+		{
+			glob:            "packages/foo/",
+			matchingPaths:   []string{"packages/foo/main.go"},
+			unmatchingPaths: []string{"packages/foobar/main.go"},
+		},
+		{
+			glob:            "packages/foo/**",
+			matchingPaths:   []string{"packages/foo/main.go", "packages/foo/cmd/main.go"},
+			unmatchingPaths: []string{"packages/foobar/main.go"},
+		},
+		{
+			glob:            "packages/*/go.mod",
+			matchingPaths:   []string{"packages/foo/go.mod"},
+			unmatchingPaths: []string{"packages/foo/bar/go.mod", "go.mod"},
+		},
+		{
+			glob:            "**/*.go",
+			matchingPaths:   []string{"main.go", "packages/foo/main.go"},
+			unmatchingPaths: []string{"README.md"},
+		},
+		{
+			glob:            "docs/v?.md",
+			matchingPaths:   []string{"docs/v1.md"},
+			unmatchingPaths: []string{"docs/v10.md", "docs/v/.md"},
+		},
+		{
+			glob:            "!packages/foo/*.md",
+			matchingPaths:   []string{"packages/foo/README.md"},
+			unmatchingPaths: []string{"packages/foo/main.go"},
+			expectedExclude: true,
+		},
+	}
+
+	for _, test := range tests {
+		pathRegex, err := util.GlobToPathRegex(test.glob)
+		require.NoError(t, err)
+
+		assert.Equal(t, test.expectedExclude, pathRegex.Exclude, test.glob)
+		for _, path := range test.matchingPaths {
+			assert.True(t, pathRegex.Regex.MatchString(path), "%s should match %s", test.glob, path)
+		}
+		for _, path := range test.unmatchingPaths {
+			assert.False(t, pathRegex.Regex.MatchString(path), "%s should not match %s", test.glob, path)
+		}
+	}
+}
@@ -0,0 +1,218 @@
+package versioning
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+const DefaultCalVerFormat = "YYYY.MM.MICRO"
+
+var ErrCalVerExhausted = errors.New("calendar version already released")
+
+type calVerSegmentKind int
+
+const (
+	calVerYear calVerSegmentKind = iota
+	calVerMonthOrWeek
+	calVerDay
+	calVerMicro
+)
+
+// calVerSegment describes a token of the format. Versions are only parsed
+// when every number matches the pattern of its token and lies within min and
+// max, so that e.g. semver tags are not mistaken for calendar versions.
+type calVerSegment struct {
+	token    string
+	kind     calVerSegmentKind
+	padded   bool
+	value    func(date time.Time) int64
+	pattern  *regexp.Regexp
+	min, max int64
+}
+
+var (
+	unpaddedNumberPattern = regexp.MustCompile(`^(0|[1-9]\d*)$`)
+	twoDigitsPattern      = regexp.MustCompile(`^\d{2}$`)
+)
+
+func isoYear(date time.Time) int64 {
+	year, _ := date.ISOWeek()
+	return int64(year)
+}
+
+func isoWeek(date time.Time) int64 {
+	_, week := date.ISOWeek()
+	return int64(week)
+}
+
+var calVerSegments = map[string]calVerSegment{
+	"YYYY":  {kind: calVerYear, value: func(date time.Time) int64 { return int64(date.Year()) }, pattern: regexp.MustCompile(`^\d{4}$`), min: 1000, max: 9999},
+	"YY":    {kind: calVerYear, value: func(date time.Time) int64 { return int64(date.Year() - 2000) }, pattern: unpaddedNumberPattern, min: 0, max: 999},
+	"0Y":    {kind: calVerYear, padded: true, value: func(date time.Time) int64 { return int64(date.Year() - 2000) }, pattern: regexp.MustCompile(`^\d{2,3}$`), min: 0, max: 999},
+	"MM":    {kind: calVerMonthOrWeek, value: func(date time.Time) int64 { return int64(date.Month()) }, pattern: unpaddedNumberPattern, min: 1, max: 12},
+	"0M":    {kind: calVerMonthOrWeek, padded: true, value: func(date time.Time) int64 { return int64(date.Month()) }, pattern: twoDigitsPattern, min: 1, max: 12},
+	"WW":    {kind: calVerMonthOrWeek, value: isoWeek, pattern: unpaddedNumberPattern, min: 1, max: 53},
+	"0W":    {kind: calVerMonthOrWeek, padded: true, value: isoWeek, pattern: twoDigitsPattern, min: 1, max: 53},
+	"DD":    {kind: calVerDay, value: func(date time.Time) int64 { return int64(date.Day()) }, pattern: unpaddedNumberPattern, min: 1, max: 31},
+	"0D":    {kind: calVerDay, padded: true, value: func(date time.Time) int64 { return int64(date.Day()) }, pattern: twoDigitsPattern, min: 1, max: 31},
+	"MICRO": {kind: calVerMicro, pattern: unpaddedNumberPattern, min: 0, max: math.MaxInt64},
+}
+
+var calVerVersionPattern = regexp.MustCompile(`^v?(\d+(?:\.\d+)*)((?:[-+].*)?)$`)
+
+/*
+CalVerScheme is the calendar versioning scheme (https://calver.org), e.g. YYYY.MM.MICRO or YY.0M.DD:
+  - the format has up to three dot-separated segments, starting with the year,
+    from the most to the least significant: YYYY, YY or 0Y, then MM, 0M, WW or
+    0W, then DD or 0D, and MICRO last
+  - every releasable change produces a version for the current date, in UTC;
+    MICRO starts at 0 and is only incremented while the date segments stay the same
+  - formats with week segments use the ISO week-numbering year
+*/
+type CalVerScheme struct {
+	segments []calVerSegment
+	now      func() time.Time
+}
+
+// NewCalVerScheme creates a calendar versioning scheme for the format. The
+// clock defaults to time.Now.
+func NewCalVerScheme(format string, now func() time.Time) (*CalVerScheme, error) {
+	if now == nil {
+		now = time.Now
+	}
+
+	tokens := strings.Split(format, ".")
+	if len(tokens) > 3 {
+		return nil, errors.New("calendar version format must have at most three segments")
+	}
+
+	scheme := &CalVerScheme{now: now}
+	usesWeeks := false
+	for i, token := range tokens {
+		segment, ok := calVerSegments[token]
+		if !ok {
+			return nil, fmt.Errorf("unknown calendar version segment %+q", token)
+		}
+		if i == 0 && segment.kind != calVerYear {
+			return nil, errors.New("calendar version format must start with the year")
+		}
+		if i > 0 && segment.kind <= scheme.segments[i-1].kind {
+			return nil, fmt.Errorf("calendar version segment %s must come before %s", token, scheme.segments[i-1].token)
+		}
+		if segment.kind == calVerDay && !strings.HasSuffix(scheme.segments[i-1].token, "M") {
+			return nil, fmt.Errorf("calendar version segment %s must follow a month", token)
+		}
+		usesWeeks = usesWeeks || token == "WW" || token == "0W"
+		segment.token = token
+		scheme.segments = append(scheme.segments, segment)
+	}
+	if usesWeeks && scheme.segments[0].token == "YYYY" {
+		scheme.segments[0].value = isoYear
+	} else if usesWeeks {
+		scheme.segments[0].value = func(date time.Time) int64 { return isoYear(date) - 2000 }
+	}
+
+	return scheme, nil
+}
+
+func (s *CalVerScheme) ParseVersion(version string) (*semver.Version, error) {
+	matches := calVerVersionPattern.FindStringSubmatch(version)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid calendar version %+q", version)
+	}
+
+	numbers := strings.Split(matches[1], ".")
+	if len(numbers) != len(s.segments) {
+		return nil, fmt.Errorf("calendar version %+q does not match the format", version)
+	}
+
+	parts := make([]int64, 3)
+	for i, number := range numbers {
+		segment := s.segments[i]
+		if !segment.pattern.MatchString(number) {
+			return nil, fmt.Errorf("calendar version %+q does not match the format, %s is not a valid %s", version, number, segment.token)
+		}
+		value, err := strconv.ParseInt(number, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid calendar version %+q: %w", version, err)
+		}
+		if value < segment.min || value > segment.max {
+			return nil, fmt.Errorf("calendar version %+q does not match the format, %s is not a valid %s", version, number, segment.token)
+		}
+		parts[i] = value
+	}
+
+	return semver.NewVersion(fmt.Sprintf("%d.%d.%d%s", parts[0], parts[1], parts[2], matches[2]))
+}
+
+func (s *CalVerScheme) NextVersion(currentVersion *semver.Version, conventionalCommitTypes []conventionalcommits.Type) (semver.Version, bool, error) {
+	isReleasable := false
+	for _, commitType := range conventionalCommitTypes {
+		isReleasable = isReleasable || commitType != conventionalcommits.Chore
+	}
+	if !isReleasable {
+		return *currentVersion, false, nil
+	}
+
+	date := s.now().UTC()
+	currentParts := versionParts(currentVersion)
+	nextParts := make([]int64, 3)
+	isSameDate := true
+	microIndex := -1
+	for i, segment := range s.segments {
+		if segment.kind == calVerMicro {
+			microIndex = i
+			continue
+		}
+		nextParts[i] = segment.value(date)
+		isSameDate = isSameDate && nextParts[i] == currentParts[i]
+	}
+
+	if isSameDate {
+		if microIndex < 0 {
+			return semver.Version{}, false, fmt.Errorf("%w: %s is the version for %s", ErrCalVerExhausted, s.FormatVersion(currentVersion), date.Format(time.DateOnly))
+		}
+		nextParts[microIndex] = currentParts[microIndex] + 1
+	}
+
+	nextVersion := semver.MustParse(fmt.Sprintf("%d.%d.%d", nextParts[0], nextParts[1], nextParts[2]))
+	if !nextVersion.GreaterThan(currentVersion) {
+		return semver.Version{}, false, fmt.Errorf("calendar version %s for %s is not greater than %s", s.FormatVersion(nextVersion), date.Format(time.DateOnly), s.FormatVersion(currentVersion))
+	}
+
+	return *nextVersion, true, nil
+}
+
+func (s *CalVerScheme) FormatVersion(version *semver.Version) string {
+	parts := versionParts(version)
+	numbers := make([]string, len(s.segments))
+	for i, segment := range s.segments {
+		if segment.padded {
+			numbers[i] = fmt.Sprintf("%02d", parts[i])
+		} else {
+			numbers[i] = strconv.FormatInt(parts[i], 10)
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(strings.Join(numbers, "."))
+	if version.Prerelease() != "" {
+		builder.WriteString("-" + version.Prerelease())
+	}
+	if version.Metadata() != "" {
+		builder.WriteString("+" + version.Metadata())
+	}
+	return builder.String()
+}
+
+func versionParts(version *semver.Version) []int64 {
+	return []int64{version.Major(), version.Minor(), version.Patch()}
+}
@@ -0,0 +1,164 @@
+package versioning_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+	"github.com/tvcsantos/get-next-version/versioning"
+)
+
+func fixedClock(date string) func() time.Time {
+	return func() time.Time {
+		now, err := time.Parse(time.DateOnly, date)
+		if err != nil {
+			panic(err)
+		}
+		return now
+	}
+}
+
+func TestNewCalVerScheme(t *testing.T) {
+	for _, format := range []string{"YYYY.MM.MICRO", "YY.0M.DD", "0Y.0W.MICRO", "YYYY.MICRO", "YYYY"} {
+		_, err := versioning.NewCalVerScheme(format, nil)
+		assert.NoError(t, err, format)
+	}
+
+	for _, format := range []string{"", "YYYY.MM.DD.MICRO", "MM.YYYY", "YYYY.MICRO.MM", "YYYY.MM.WW", "YYYY.DD", "YYYY.0WW"} {
+		_, err := versioning.NewCalVerScheme(format, nil)
+		assert.Error(t, err, format)
+	}
+}
+
+func TestCalVerSchemeNextVersion(t *testing.T) {
+	tests := []struct {
+		name                    string
+		format                  string
+		date                    string
+		currentVersion          string
+		conventionalCommitTypes []conventionalcommits.Type
+		doExpectError           bool
+		expectedVersion         string
+		expectedHasNextVersion  bool
+	}{
+		{
+			name:                    "first release",
+			format:                  "YYYY.MM.MICRO",
+			date:                    "2024-10-16",
+			currentVersion:          "0.0.0",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			expectedVersion:         "2024.10.0",
+			expectedHasNextVersion:  true,
+		},
+		{
+			name:                    "increments micro within the same month",
+			format:                  "YYYY.MM.MICRO",
+			date:                    "2024-10-16",
+			currentVersion:          "2024.10.3",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Feature},
+			expectedVersion:         "2024.10.4",
+			expectedHasNextVersion:  true,
+		},
+		{
+			name:                    "resets micro when the month changes",
+			format:                  "YYYY.MM.MICRO",
+			date:                    "2024-11-01",
+			currentVersion:          "2024.10.3",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.BreakingChange},
+			expectedVersion:         "2024.11.0",
+			expectedHasNextVersion:  true,
+		},
+		{
+			name:                    "keeps the version without releasable changes",
+			format:                  "YYYY.MM.MICRO",
+			date:                    "2024-11-01",
+			currentVersion:          "2024.10.3",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Chore},
+			expectedVersion:         "2024.10.3",
+			expectedHasNextVersion:  false,
+		},
+		{
+			name:                    "uses the date without micro",
+			format:                  "YY.0M.DD",
+			date:                    "2024-01-05",
+			currentVersion:          "23.12.20",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			expectedVersion:         "24.01.5",
+			expectedHasNextVersion:  true,
+		},
+		{
+			name:                    "fails on a second release of the same date without micro",
+			format:                  "YY.0M.DD",
+			date:                    "2024-01-05",
+			currentVersion:          "24.1.5",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			doExpectError:           true,
+		},
+		{
+			name:                    "fails when the clock is behind the latest release",
+			format:                  "YYYY.MM.MICRO",
+			date:                    "2024-09-30",
+			currentVersion:          "2024.10.3",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			doExpectError:           true,
+		},
+		{
+			name:                    "uses the ISO week-numbering year",
+			format:                  "YYYY.0W.MICRO",
+			date:                    "2024-12-30",
+			currentVersion:          "2024.52.1",
+			conventionalCommitTypes: []conventionalcommits.Type{conventionalcommits.Fix},
+			expectedVersion:         "2025.01.0",
+			expectedHasNextVersion:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			scheme, err := versioning.NewCalVerScheme(test.format, fixedClock(test.date))
+			require.NoError(t, err)
+
+			actual, hasNextVersion, err := scheme.NextVersion(semver.MustParse(test.currentVersion), test.conventionalCommitTypes)
+
+			if test.doExpectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedVersion, scheme.FormatVersion(&actual))
+			assert.Equal(t, test.expectedHasNextVersion, hasNextVersion)
+		})
+	}
+}
+
+func TestCalVerSchemeParseVersion(t *testing.T) {
+	scheme, err := versioning.NewCalVerScheme("YY.0M.MICRO", nil)
+	require.NoError(t, err)
+
+	version, err := scheme.ParseVersion("v24.01.3-rc.1+build")
+	require.NoError(t, err)
+	assert.Equal(t, "24.1.3-rc.1+build", version.String())
+	assert.Equal(t, "24.01.3-rc.1+build", scheme.FormatVersion(version))
+
+	for _, invalid := range []string{"24.01", "24.01.3.1", "24.x.3", "release-24.01.3", "24.1.3", "24.13.0", "24.00.0", "24.01.03"} {
+		_, err := scheme.ParseVersion(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestCalVerSchemeParseVersionIgnoresSemVerTags(t *testing.T) {
+	scheme, err := versioning.NewCalVerScheme(versioning.DefaultCalVerFormat, nil)
+	require.NoError(t, err)
+
+	for _, semverTag := range []string{"v1.2.3", "v0.9.0", "v10.0.1"} {
+		_, err := scheme.ParseVersion(semverTag)
+		assert.Error(t, err, semverTag)
+	}
+
+	version, err := scheme.ParseVersion("v2026.10.3")
+	require.NoError(t, err)
+	assert.Equal(t, "2026.10.3", scheme.FormatVersion(version))
+}
@@ -1,14 +1,61 @@
 package versioning
 
 import (
+	"fmt"
+
 	"github.com/Masterminds/semver"
 	"github.com/tvcsantos/get-next-version/conventionalcommits"
 )
 
+type Bump string
+
+const (
+	BumpNone  Bump = "none"
+	BumpPatch Bump = "patch"
+	BumpMinor Bump = "minor"
+	BumpMajor Bump = "major"
+)
+
+/*
+Options changes how the next version is calculated
+  - InitialDevelopment applies SemVer's initial development (0.x) rules while
+    the major version is 0: breaking changes bump the minor version and
+    features bump the version given by InitialDevelopmentFeatureBump
+  - InitialDevelopmentFeatureBump is either BumpPatch (default) or BumpMinor
+  - Graduate releases 1.0.0 if the major version is 0, regardless of the commits
+*/
+type Options struct {
+	InitialDevelopment            bool
+	InitialDevelopmentFeatureBump Bump
+	Graduate                      bool
+}
+
+func IsValidInitialDevelopmentFeatureBump(bump Bump) (bool, error) {
+	switch bump {
+	case "", BumpPatch, BumpMinor:
+		return true, nil
+	default:
+		return false, fmt.Errorf("feature bump must be %s or %s", BumpPatch, BumpMinor)
+	}
+}
+
 func CalculateNextVersion(
 	currentVersion *semver.Version,
 	conventionalCommitTypes []conventionalcommits.Type,
 ) (semver.Version, bool) {
+	return CalculateNextVersionWithOptions(currentVersion, conventionalCommitTypes, Options{})
+}
+
+func CalculateNextVersionWithOptions(
+	currentVersion *semver.Version,
+	conventionalCommitTypes []conventionalcommits.Type,
+	options Options,
+) (semver.Version, bool) {
+	isInitialDevelopment := currentVersion.Major() == 0
+	if options.Graduate && isInitialDevelopment {
+		return *semver.MustParse("1.0.0"), true
+	}
+
 	currentlyDetectedChange := conventionalcommits.Chore
 	for _, commitType := range conventionalCommitTypes {
 		if commitType > currentlyDetectedChange {
@@ -19,16 +66,44 @@ func CalculateNextVersion(
 		}
 	}
 
-	switch currentlyDetectedChange {
-	case conventionalcommits.Chore:
+	bump := toBump(currentlyDetectedChange)
+	if options.InitialDevelopment && isInitialDevelopment {
+		switch bump {
+		case BumpMajor:
+			bump = BumpMinor
+		case BumpMinor:
+			bump = BumpPatch
+			if options.InitialDevelopmentFeatureBump == BumpMinor {
+				bump = BumpMinor
+			}
+		}
+	}
+
+	switch bump {
+	case BumpNone:
 		return *currentVersion, false
-	case conventionalcommits.Fix:
+	case BumpPatch:
 		return currentVersion.IncPatch(), true
-	case conventionalcommits.Feature:
+	case BumpMinor:
 		return currentVersion.IncMinor(), true
-	case conventionalcommits.BreakingChange:
+	case BumpMajor:
 		return currentVersion.IncMajor(), true
 	}
 
+	panic("invalid bump")
+}
+
+func toBump(conventionalCommitType conventionalcommits.Type) Bump {
+	switch conventionalCommitType {
+	case conventionalcommits.Chore:
+		return BumpNone
+	case conventionalcommits.Fix:
+		return BumpPatch
+	case conventionalcommits.Feature:
+		return BumpMinor
+	case conventionalcommits.BreakingChange:
+		return BumpMajor
+	}
+
 	panic("invalid conventional commit type")
 }
@@ -102,3 +102,99 @@ func TestCalculateNextVersion(t *testing.T) {
 		assert.Equal(t, test.expectedHasNewVersion, hasNewVersion)
 	}
 }
+
+func TestCalculateNextVersionWithOptions(t *testing.T) {
+	initialDevelopment := versioning.Options{InitialDevelopment: true}
+	initialDevelopmentWithMinorFeatures := versioning.Options{InitialDevelopment: true, InitialDevelopmentFeatureBump: versioning.BumpMinor}
+	graduate := versioning.Options{InitialDevelopment: true, Graduate: true}
+
+	tests := []struct {
+		name                   string
+		currentVersion         *semver.Version
+		conventionalCommitType []conventionalcommits.Type
+		options                versioning.Options
+		expectedNewVersion     *semver.Version
+		expectedHasNewVersion  bool
+	}{
+		{
+			name:                   "breaking change bumps major without options",
+			currentVersion:         semver.MustParse("0.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.BreakingChange},
+			options:                versioning.Options{},
+			expectedNewVersion:     semver.MustParse("1.0.0"),
+			expectedHasNewVersion:  true,
+		},
+		{
+			name:                   "breaking change bumps minor in initial development",
+			currentVersion:         semver.MustParse("0.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.Fix, conventionalcommits.BreakingChange},
+			options:                initialDevelopment,
+			expectedNewVersion:     semver.MustParse("0.5.0"),
+			expectedHasNewVersion:  true,
+		},
+		{
+			name:                   "feature bumps patch in initial development",
+			currentVersion:         semver.MustParse("0.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.Feature},
+			options:                initialDevelopment,
+			expectedNewVersion:     semver.MustParse("0.4.3"),
+			expectedHasNewVersion:  true,
+		},
+		{
+			name:                   "feature bumps minor in initial development if configured",
+			currentVersion:         semver.MustParse("0.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.Feature},
+			options:                initialDevelopmentWithMinorFeatures,
+			expectedNewVersion:     semver.MustParse("0.5.0"),
+			expectedHasNewVersion:  true,
+		},
+		{
+			name:                   "fix bumps patch in initial development",
+			currentVersion:         semver.MustParse("0.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.Fix},
+			options:                initialDevelopment,
+			expectedNewVersion:     semver.MustParse("0.4.3"),
+			expectedHasNewVersion:  true,
+		},
+		{
+			name:                   "chore does not bump in initial development",
+			currentVersion:         semver.MustParse("0.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.Chore},
+			options:                initialDevelopment,
+			expectedNewVersion:     semver.MustParse("0.4.2"),
+			expectedHasNewVersion:  false,
+		},
+		{
+			name:                   "initial development does not apply after 1.0.0",
+			currentVersion:         semver.MustParse("1.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.BreakingChange},
+			options:                initialDevelopment,
+			expectedNewVersion:     semver.MustParse("2.0.0"),
+			expectedHasNewVersion:  true,
+		},
+		{
+			name:                   "graduate releases 1.0.0",
+			currentVersion:         semver.MustParse("0.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.Chore},
+			options:                graduate,
+			expectedNewVersion:     semver.MustParse("1.0.0"),
+			expectedHasNewVersion:  true,
+		},
+		{
+			name:                   "graduate does not apply after 1.0.0",
+			currentVersion:         semver.MustParse("1.4.2"),
+			conventionalCommitType: []conventionalcommits.Type{conventionalcommits.Fix},
+			options:                graduate,
+			expectedNewVersion:     semver.MustParse("1.4.3"),
+			expectedHasNewVersion:  true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actualNewVersion, hasNewVersion := versioning.CalculateNextVersionWithOptions(test.currentVersion, test.conventionalCommitType, test.options)
+			assert.Equal(t, test.expectedNewVersion.String(), actualNewVersion.String())
+			assert.Equal(t, test.expectedHasNewVersion, hasNewVersion)
+		})
+	}
+}
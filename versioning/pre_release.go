@@ -0,0 +1,67 @@
+package versioning
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/Masterminds/semver"
+)
+
+var preReleaseChannelValidation = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+
+func IsValidPreReleaseChannel(channel string) (bool, error) {
+	if !preReleaseChannelValidation.MatchString(channel) {
+		return false, errors.New("pre-release channel must only contain alphanumerics, hyphens and dots")
+	}
+
+	return true, nil
+}
+
+/*
+CalculatePreReleaseVersion turns the next version into a pre-release on the given channel.
+The counter is scoped to the next version and the channel:
+  - the first pre-release of a version on a channel gets the counter 1 (e.g. 2.0.0-rc.1)
+  - every further pre-release increments the highest existing counter (e.g. 2.0.0-rc.2)
+  - when a higher bump changes the next version (e.g. from 1.1.0 to 2.0.0), or a
+    different channel is used, the counter starts at 1 again
+*/
+func CalculatePreReleaseVersion(nextVersion semver.Version, channel string, existingVersions []*semver.Version) (semver.Version, error) {
+	if isValid, err := IsValidPreReleaseChannel(channel); !isValid {
+		return semver.Version{}, err
+	}
+
+	counterRegex := regexp.MustCompile(`^` + regexp.QuoteMeta(channel) + `\.(\d+)$`)
+
+	var highestCounter int64
+	for _, existingVersion := range existingVersions {
+		if !hasSameCoreVersion(existingVersion, &nextVersion) {
+			continue
+		}
+
+		matches := counterRegex.FindStringSubmatch(existingVersion.Prerelease())
+		if matches == nil {
+			continue
+		}
+
+		counter, err := strconv.ParseInt(matches[1], 10, 64)
+		if err != nil {
+			continue
+		}
+		if counter > highestCounter {
+			highestCounter = counter
+		}
+	}
+
+	return nextVersion.SetPrerelease(fmt.Sprintf("%s.%d", channel, highestCounter+1))
+}
+
+func IsPreReleaseOf(version *semver.Version, nextVersion semver.Version, channel string) bool {
+	counterRegex := regexp.MustCompile(`^` + regexp.QuoteMeta(channel) + `\.\d+$`)
+	return hasSameCoreVersion(version, &nextVersion) && counterRegex.MatchString(version.Prerelease())
+}
+
+func hasSameCoreVersion(left, right *semver.Version) bool {
+	return left.Major() == right.Major() && left.Minor() == right.Minor() && left.Patch() == right.Patch()
+}
@@ -0,0 +1,103 @@
+package versioning_test
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/tvcsantos/get-next-version/versioning"
+)
+
+func TestCalculatePreReleaseVersion(t *testing.T) {
+	tests := []struct {
+		name             string
+		nextVersion      string
+		channel          string
+		existingVersions []string
+		doExpectError    bool
+		expectedVersion  string
+	}{
+		{
+			name:             "first pre-release of a version",
+			nextVersion:      "2.0.0",
+			channel:          "rc",
+			existingVersions: []string{"1.0.0", "1.1.0"},
+			expectedVersion:  "2.0.0-rc.1",
+		},
+		{
+			name:             "increments the highest existing counter",
+			nextVersion:      "2.0.0",
+			channel:          "rc",
+			existingVersions: []string{"1.0.0", "2.0.0-rc.1", "2.0.0-rc.3", "2.0.0-rc.2"},
+			expectedVersion:  "2.0.0-rc.4",
+		},
+		{
+			name:             "higher bump resets the counter",
+			nextVersion:      "2.0.0",
+			channel:          "rc",
+			existingVersions: []string{"1.0.0", "1.1.0-rc.1", "1.1.0-rc.2"},
+			expectedVersion:  "2.0.0-rc.1",
+		},
+		{
+			name:             "counters are scoped per channel",
+			nextVersion:      "1.1.0",
+			channel:          "rc",
+			existingVersions: []string{"1.1.0-beta.1", "1.1.0-beta.2"},
+			expectedVersion:  "1.1.0-rc.1",
+		},
+		{
+			name:             "ignores pre-releases that are not counted",
+			nextVersion:      "1.1.0",
+			channel:          "rc",
+			existingVersions: []string{"1.1.0-rc", "1.1.0-rc.x", "1.1.0-rc.1.1", "1.1.0-rcx.5"},
+			expectedVersion:  "1.1.0-rc.1",
+		},
+		{
+			name:             "supports dotted channels",
+			nextVersion:      "1.1.0",
+			channel:          "beta.next",
+			existingVersions: []string{"1.1.0-beta.next.4"},
+			expectedVersion:  "1.1.0-beta.next.5",
+		},
+		{
+			name:          "rejects invalid channels",
+			nextVersion:   "1.1.0",
+			channel:       "rc_1",
+			doExpectError: true,
+		},
+		{
+			name:          "rejects empty channels",
+			nextVersion:   "1.1.0",
+			channel:       "",
+			doExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var existingVersions []*semver.Version
+			for _, existingVersion := range test.existingVersions {
+				existingVersions = append(existingVersions, semver.MustParse(existingVersion))
+			}
+
+			actual, err := versioning.CalculatePreReleaseVersion(*semver.MustParse(test.nextVersion), test.channel, existingVersions)
+
+			if test.doExpectError {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedVersion, actual.String())
+		})
+	}
+}
+
+func TestIsPreReleaseOf(t *testing.T) {
+	nextVersion := *semver.MustParse("2.0.0")
+
+	assert.True(t, versioning.IsPreReleaseOf(semver.MustParse("2.0.0-rc.1"), nextVersion, "rc"))
+	assert.False(t, versioning.IsPreReleaseOf(semver.MustParse("2.0.0-beta.1"), nextVersion, "rc"))
+	assert.False(t, versioning.IsPreReleaseOf(semver.MustParse("1.1.0-rc.1"), nextVersion, "rc"))
+	assert.False(t, versioning.IsPreReleaseOf(semver.MustParse("2.0.0"), nextVersion, "rc"))
+}
@@ -0,0 +1,48 @@
+package versioning
+
+import (
+	"github.com/Masterminds/semver"
+	"github.com/tvcsantos/get-next-version/conventionalcommits"
+)
+
+const (
+	SchemeSemVer = "semver"
+	SchemeCalVer = "calver"
+)
+
+/*
+Scheme is a versioning scheme. Versions of every scheme are represented as
+semver.Version, with the segments of the scheme in the major, minor and patch
+numbers, so that tags of all schemes are compared and stored alike:
+  - ParseVersion parses the version of a tag, without the tag prefix
+  - NextVersion calculates the version that follows currentVersion for the
+    given changes, and reports false if none of them is releasable
+  - FormatVersion renders a version the way it is written in tags
+*/
+type Scheme interface {
+	ParseVersion(version string) (*semver.Version, error)
+	NextVersion(currentVersion *semver.Version, conventionalCommitTypes []conventionalcommits.Type) (semver.Version, bool, error)
+	FormatVersion(version *semver.Version) string
+}
+
+// SemVerScheme is the semantic versioning scheme, see CalculateNextVersionWithOptions.
+type SemVerScheme struct {
+	options Options
+}
+
+func NewSemVerScheme(options Options) *SemVerScheme {
+	return &SemVerScheme{options: options}
+}
+
+func (s *SemVerScheme) ParseVersion(version string) (*semver.Version, error) {
+	return semver.NewVersion(version)
+}
+
+func (s *SemVerScheme) NextVersion(currentVersion *semver.Version, conventionalCommitTypes []conventionalcommits.Type) (semver.Version, bool, error) {
+	nextVersion, hasNextVersion := CalculateNextVersionWithOptions(currentVersion, conventionalCommitTypes, s.options)
+	return nextVersion, hasNextVersion, nil
+}
+
+func (s *SemVerScheme) FormatVersion(version *semver.Version) string {
+	return version.String()
+}
@@ -0,0 +1,27 @@
+package versioning
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Masterminds/semver"
+)
+
+/*
+CalculateSnapshotVersion turns a version into a unique development snapshot, similar to git describe:
+  - the pre-release is the identifier followed by the number of commits since the
+    baseline (e.g. 1.4.0-dev.7), so snapshots of the same version sort by commit count
+  - the build metadata identifiers, if any, are joined with dots (e.g. 1.4.0-dev.7+g3f2a9c1.dirty)
+*/
+func CalculateSnapshotVersion(version semver.Version, identifier string, commitCount int, metadata []string) (semver.Version, error) {
+	if isValid, err := IsValidPreReleaseChannel(identifier); !isValid {
+		return semver.Version{}, err
+	}
+
+	snapshotVersion, err := version.SetPrerelease(fmt.Sprintf("%s.%d", identifier, commitCount))
+	if err != nil {
+		return semver.Version{}, err
+	}
+
+	return snapshotVersion.SetMetadata(strings.Join(metadata, "."))
+}
@@ -0,0 +1,72 @@
+package versioning_test
+
+import (
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/tvcsantos/get-next-version/versioning"
+)
+
+func TestCalculateSnapshotVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		version         string
+		identifier      string
+		commitCount     int
+		metadata        []string
+		doExpectError   bool
+		expectedVersion string
+	}{
+		{
+			name:            "counts commits since the baseline",
+			version:         "1.4.0",
+			identifier:      "dev",
+			commitCount:     7,
+			expectedVersion: "1.4.0-dev.7",
+		},
+		{
+			name:            "appends build metadata",
+			version:         "1.4.0",
+			identifier:      "dev",
+			commitCount:     7,
+			metadata:        []string{"g3f2a9c1", "dirty"},
+			expectedVersion: "1.4.0-dev.7+g3f2a9c1.dirty",
+		},
+		{
+			name:            "replaces an existing pre-release and metadata",
+			version:         "2.0.0-rc.1+old",
+			identifier:      "nightly.pr-12",
+			commitCount:     0,
+			expectedVersion: "2.0.0-nightly.pr-12.0",
+		},
+		{
+			name:          "rejects invalid identifiers",
+			version:       "1.4.0",
+			identifier:    "dev_build",
+			commitCount:   1,
+			doExpectError: true,
+		},
+		{
+			name:          "rejects invalid metadata",
+			version:       "1.4.0",
+			identifier:    "dev",
+			commitCount:   1,
+			metadata:      []string{"g3f2+a9c1"},
+			doExpectError: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := versioning.CalculateSnapshotVersion(*semver.MustParse(test.version), test.identifier, test.commitCount, test.metadata)
+
+			if test.doExpectError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expectedVersion, actual.String())
+		})
+	}
+}